@@ -0,0 +1,28 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.json")
+
+	assert.Nil(t, Write(path, []byte("first"), 0644))
+	assert.Nil(t, Write(path, []byte("second"), 0644))
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", string(data), "Write() should replace the file's contents")
+
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1, "Write() should not leave temporary files behind")
+
+	assert.NotNil(t, Write(filepath.Join(dir, "missing", "file.json"), []byte("x"), 0644),
+		"Write() should fail when the directory does not exist")
+}
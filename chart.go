@@ -0,0 +1,144 @@
+package main
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/plot"
+	"speedruntimer/timing/stats"
+)
+
+// chartPoint is one value of a charted series. Values are plain numbers, so that anything can be charted.
+type chartPoint struct {
+	Date  time.Time
+	Value float64
+}
+
+// durationPoints converts a series of times to be charted, as nanoseconds.
+func durationPoints(points []stats.Point) []chartPoint {
+	out := make([]chartPoint, len(points))
+	for i, p := range points {
+		out[i] = chartPoint{p.Date, float64(p.Value)}
+	}
+	return out
+}
+
+// timeChart plots a series of values over time as a line, with the range of values labelled.
+type timeChart struct {
+	widget.BaseWidget
+
+	points []chartPoint
+	// format writes a value for the labels.
+	format func(float64) string
+}
+
+func newTimeChart(points []chartPoint, format func(float64) string) *timeChart {
+	c := &timeChart{points: points, format: format}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *timeChart) CreateRenderer() fyne.WidgetRenderer {
+	r := &timeChartRenderer{
+		chart: c,
+		max:   canvas.NewText("", themeColor(theme.ColorNameForeground)),
+		min:   canvas.NewText("", themeColor(theme.ColorNameForeground)),
+		empty: canvas.NewText("Not enough dated attempts to chart yet.", themeColor(theme.ColorNameForeground)),
+	}
+	r.Refresh()
+	return r
+}
+
+type timeChartRenderer struct {
+	chart *timeChart
+
+	// points are the chart's points as of the last Refresh, which line was built for,
+	// and low and high the range of their values.
+	points    []chartPoint
+	low, high float64
+
+	max, min, empty *canvas.Text
+	line            *plot.Line
+	objects         []fyne.CanvasObject
+}
+
+func (r *timeChartRenderer) Layout(size fyne.Size) {
+	r.empty.Move(fyne.NewPos(0, 0))
+	if len(r.points) < 2 {
+		return
+	}
+
+	// leave room on the left for the labels
+	left := r.max.MinSize().Width + theme.Padding()
+	if w := r.min.MinSize().Width + theme.Padding(); w > left {
+		left = w
+	}
+	r.max.Move(fyne.NewPos(0, 0))
+	r.min.Move(fyne.NewPos(0, size.Height-r.min.MinSize().Height))
+
+	first, last := r.points[0].Date, r.points[len(r.points)-1].Date
+	var positions []fyne.Position
+	for _, p := range r.points {
+		x, y := float32(0), float32(0.5)
+		if span := last.Sub(first); span > 0 {
+			x = float32(p.Date.Sub(first)) / float32(span)
+		}
+		if r.high > r.low {
+			y = float32((r.high - p.Value) / (r.high - r.low))
+		}
+		positions = append(positions, fyne.NewPos(left+x*(size.Width-left), y*size.Height))
+	}
+	r.line.Place(positions)
+}
+
+func (r *timeChartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(200, 150)
+}
+
+// Refresh rebuilds the line, since the number of points can change.
+func (r *timeChartRenderer) Refresh() {
+	r.points = append([]chartPoint(nil), r.chart.points...)
+	for _, text := range []*canvas.Text{r.max, r.min, r.empty} {
+		text.Color = themeColor(theme.ColorNameForeground)
+	}
+
+	if len(r.points) < 2 {
+		r.line = plot.NewLine()
+		r.objects = []fyne.CanvasObject{r.empty}
+		canvas.Refresh(r.chart)
+		return
+	}
+
+	r.low, r.high = r.points[0].Value, r.points[0].Value
+	for _, p := range r.points {
+		if p.Value < r.low {
+			r.low = p.Value
+		}
+		if p.Value > r.high {
+			r.high = p.Value
+		}
+	}
+	r.max.Text = r.chart.format(r.high)
+	r.min.Text = r.chart.format(r.low)
+
+	colors := make([]color.Color, len(r.points)-1)
+	for i := range colors {
+		colors[i] = themeColor(theme.ColorNamePrimary)
+	}
+	r.line = plot.NewLine(colors...)
+	r.objects = append([]fyne.CanvasObject{r.max, r.min}, r.line.Objects()...)
+
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *timeChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *timeChartRenderer) Destroy() {}
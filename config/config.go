@@ -2,58 +2,158 @@ package config
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
+	"fyne.io/fyne/v2"
 	"github.com/adrg/xdg"
-	"github.com/jinzhu/configor"
+
+	"speedruntimer/atomicfile"
 )
 
+// CurrentVersion is the version of the config file layout written by this build.
+// Older files are migrated up to it when opened; see migrate.go.
+const CurrentVersion = 1
+
 type Config struct {
+	Version int
+
 	LastSplitFile string
+	LayoutFile    string
+	ThemeFile     string
+
+	// RecentSplitFiles lists split files that have been opened, most recent first.
+	RecentSplitFiles []string
+
+	// WindowSizes holds the timer window's size for each split file, keyed by path.
+	WindowSizes map[string]WindowSize
+
+	Hotkeys Hotkeys
+	// Debounce is how soon after each hotkey is pressed that further presses are ignored.
+	Debounce Debounce
+	// RefreshRate is how many times per second the clocks are redrawn.
+	RefreshRate int
+	// SplitBackups is how many earlier versions of each split file are kept.
+	SplitBackups int
+
+	// Integrations configures connections to other programs. None are built in yet;
+	// their settings are kept as they are so that they survive a save.
+	Integrations map[string]json.RawMessage `json:",omitempty"`
 }
 
-var default_config = Config{
-	LastSplitFile: "",
+// Debounce holds a time for each of the Hotkeys.
+type Debounce struct {
+	Split     time.Duration
+	Pause     time.Duration
+	Reset     time.Duration
+	UndoReset time.Duration
+}
+
+type WindowSize struct {
+	Width, Height float32
+}
+
+// Hotkeys are the keys that control the timer, as fyne key names.
+type Hotkeys struct {
+	Split     fyne.KeyName
+	Pause     fyne.KeyName
+	Reset     fyne.KeyName
+	UndoReset fyne.KeyName
+}
+
+// Default returns the config used when there is no config file.
+func Default() *Config {
+	return &Config{
+		Version: CurrentVersion,
+		Hotkeys: Hotkeys{
+			Split:     fyne.KeyReturn,
+			Pause:     fyne.KeySpace,
+			Reset:     fyne.KeyBackspace,
+			UndoReset: fyne.KeyZ,
+		},
+		Debounce: Debounce{
+			Split: 250 * time.Millisecond,
+			Pause: 250 * time.Millisecond,
+			Reset: 250 * time.Millisecond,
+		},
+		RefreshRate:  60,
+		SplitBackups: 10,
+	}
 }
 
 const config_path = "speedruntimer/config"
 
-func OpenConfigFile() (*Config, error) {
-	cfgfilename, searcherr := xdg.SearchConfigFile(config_path)
-	if searcherr != nil {
-		// Assume the file does not exist; create default one
-		var createerr error
-		cfgfilename, createerr = createConfigFile()
-		if createerr != nil {
-			return nil, createerr
-		}
+// Open loads the config file, creating it if it does not exist yet.
+//
+// A usable config is always returned, even alongside an error: if the file cannot be parsed
+// it is moved aside and the defaults are used, and any invalid settings are reset to their defaults.
+func Open() (*Config, error) {
+	path, err := xdg.ConfigFile(config_path)
+	if err != nil {
+		return Default(), err
 	}
 
-	conf := &Config{}
-	lderr := configor.Load(conf, cfgfilename)
-	if lderr != nil {
-		return nil, lderr
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		conf := Default()
+		return conf, conf.Save()
+	}
+	if err != nil {
+		return Default(), err
 	}
 
-	return conf, nil
+	conf, err := parse(data)
+	if err != nil {
+		// keep the broken file rather than overwriting it on the next save
+		broken := path + ".broken"
+		if renameerr := os.Rename(path, broken); renameerr != nil {
+			return Default(), fmt.Errorf("config file could not be read: %w", err)
+		}
+		return Default(), fmt.Errorf("config file could not be read, and was moved to %s: %w", broken, err)
+	}
+
+	return conf, conf.Validate()
 }
 
-func createConfigFile() (string, error) {
-	s, _ := xdg.ConfigFile(config_path) // Unhandled potential error
-	newfile, filecreateerr := os.Create(s)
-	if filecreateerr != nil {
-		log.Print("config search error -> file create error")
-		return "", filecreateerr
+// Save writes the config file. The write is atomic, so a crash cannot leave it half written.
+func (c *Config) Save() error {
+	path, err := xdg.ConfigFile(config_path)
+	if err != nil {
+		return err
 	}
 
-	confbytes, marshalerr := json.Marshal(default_config)
-	if marshalerr != nil {
-		log.Print("config search error -> json marshal error")
-		return "", marshalerr
+	c.Version = CurrentVersion
+	data, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		return err
 	}
 
-	newfile.Write(confbytes)
+	return atomicfile.Write(path, data, 0644)
+}
+
+const maxRecentSplitFiles = 10
 
-	return s, nil
+// AddRecentSplitFile moves path to the front of the recent split files,
+// dropping the oldest if there are too many.
+func (c *Config) AddRecentSplitFile(path string) {
+	recent := []string{path}
+	for _, p := range c.RecentSplitFiles {
+		if p != path && len(recent) < maxRecentSplitFiles {
+			recent = append(recent, p)
+		}
+	}
+	c.RecentSplitFiles = recent
+}
+
+// PruneRecentSplitFiles removes any recent split files that no longer exist.
+func (c *Config) PruneRecentSplitFiles() {
+	var recent []string
+	for _, p := range c.RecentSplitFiles {
+		if _, err := os.Stat(p); err == nil {
+			recent = append(recent, p)
+		}
+	}
+	c.RecentSplitFiles = recent
 }
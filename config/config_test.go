@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRecentSplitFile(t *testing.T) {
+	c := &Config{}
+	c.AddRecentSplitFile("a.json")
+	c.AddRecentSplitFile("b.json")
+	assert.Equal(t, []string{"b.json", "a.json"}, c.RecentSplitFiles,
+		"the most recent file comes first")
+
+	c.AddRecentSplitFile("a.json")
+	assert.Equal(t, []string{"a.json", "b.json"}, c.RecentSplitFiles,
+		"reopening a file moves it to the front instead of adding it again")
+
+	for i := 0; i < 2*maxRecentSplitFiles; i++ {
+		c.AddRecentSplitFile(fmt.Sprint(i))
+	}
+	assert.Len(t, c.RecentSplitFiles, maxRecentSplitFiles, "the list is capped")
+	assert.Equal(t, fmt.Sprint(2*maxRecentSplitFiles-1), c.RecentSplitFiles[0])
+}
+
+func TestPruneRecentSplitFiles(t *testing.T) {
+	dir := t.TempDir()
+	exists := filepath.Join(dir, "exists.json")
+	assert.Nil(t, os.WriteFile(exists, []byte("{}"), 0644))
+
+	c := &Config{RecentSplitFiles: []string{filepath.Join(dir, "missing.json"), exists}}
+	c.PruneRecentSplitFiles()
+	assert.Equal(t, []string{exists}, c.RecentSplitFiles, "missing files are removed")
+}
+
+func TestParse(t *testing.T) {
+	conf, err := parse([]byte(`{"LastSplitFile":"run.json","LayoutFile":"layout.json"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, CurrentVersion, conf.Version, "unversioned config files are brought up to the current version")
+	assert.Equal(t, "run.json", conf.LastSplitFile)
+	assert.Equal(t, "layout.json", conf.LayoutFile)
+	assert.Equal(t, Default().Hotkeys, conf.Hotkeys, "settings missing from the file keep their defaults")
+
+	_, err = parse([]byte(`{"Version":999}`))
+	assert.NotNil(t, err, "config files from newer versions are refused")
+
+	_, err = parse([]byte(`{"Version":0}`))
+	assert.NotNil(t, err, "config files with versions before the first are refused")
+	_, err = parse([]byte(`{"Version":-1}`))
+	assert.NotNil(t, err, "config files with versions before the first are refused")
+
+	_, err = parse([]byte(`{`))
+	assert.NotNil(t, err, "broken config files are refused")
+}
+
+func TestMigrate(t *testing.T) {
+	assert.Equal(t, CurrentVersion, len(migrations)+1, "there is a migration up to every version")
+
+	var applied []int
+	steps := []func(raw map[string]interface{}){
+		func(raw map[string]interface{}) { applied = append(applied, 1) },
+		func(raw map[string]interface{}) {
+			applied = append(applied, 2)
+			raw["Renamed"] = raw["Old"]
+			delete(raw, "Old")
+		},
+	}
+
+	raw := map[string]interface{}{"Old": "value"}
+	migrate(raw, 1, steps)
+	assert.Equal(t, []int{1, 2}, applied, "every step from the file's version on is applied, in order")
+	assert.Equal(t, map[string]interface{}{"Renamed": "value"}, raw, "the steps change the file")
+
+	applied = nil
+	migrate(map[string]interface{}{}, 2, steps)
+	assert.Equal(t, []int{2}, applied, "steps before the file's version are skipped")
+
+	applied = nil
+	migrate(map[string]interface{}{}, 3, steps)
+	assert.Empty(t, applied, "a file at the latest version is left alone")
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Default().Validate(), "the default config is valid")
+
+	conf := Default()
+	conf.Hotkeys.Pause = conf.Hotkeys.Split
+	conf.RefreshRate = 0
+	conf.WindowSizes = map[string]WindowSize{"run.json": {Width: -1, Height: 10}}
+
+	err := conf.Validate()
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Len(t, err.(*ValidationError).Problems, 3, "every problem is reported")
+	assert.Equal(t, Default().Hotkeys, conf.Hotkeys, "invalid settings are reset to their defaults")
+	assert.Equal(t, Default().RefreshRate, conf.RefreshRate, "invalid settings are reset to their defaults")
+	assert.Empty(t, conf.WindowSizes, "invalid window sizes are forgotten")
+
+	conf = Default()
+	conf.Hotkeys.Reset = "Backspace"
+	assert.NotNil(t, conf.Validate(), "hotkeys must be fyne key names")
+	assert.Equal(t, Default().Hotkeys, conf.Hotkeys)
+}
+
+func TestOpenSave(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	defer xdg.Reload()
+
+	conf, err := Open()
+	assert.Nil(t, err, "Open() creates the config file if there is none")
+	assert.Equal(t, Default(), conf)
+
+	conf.LastSplitFile = "run.json"
+	assert.Nil(t, conf.Save())
+
+	conf, err = Open()
+	assert.Nil(t, err)
+	assert.Equal(t, "run.json", conf.LastSplitFile, "saved settings are loaded again")
+
+	path, _ := xdg.ConfigFile(config_path)
+	assert.Nil(t, os.WriteFile(path, []byte("{"), 0644))
+	conf, err = Open()
+	assert.NotNil(t, err, "a broken config file is reported")
+	assert.Equal(t, Default(), conf, "the defaults are used in place of a broken config file")
+	_, statErr := os.Stat(path + ".broken")
+	assert.Nil(t, statErr, "a broken config file is kept")
+}
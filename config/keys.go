@@ -0,0 +1,84 @@
+package config
+
+import "fyne.io/fyne/v2"
+
+// keyNames are the keys fyne reports presses of, and so the only ones that can be hotkeys.
+var keyNames = map[fyne.KeyName]bool{
+	fyne.KeyEscape:       true,
+	fyne.KeyReturn:       true,
+	fyne.KeyTab:          true,
+	fyne.KeyBackspace:    true,
+	fyne.KeyInsert:       true,
+	fyne.KeyDelete:       true,
+	fyne.KeyRight:        true,
+	fyne.KeyLeft:         true,
+	fyne.KeyDown:         true,
+	fyne.KeyUp:           true,
+	fyne.KeyPageUp:       true,
+	fyne.KeyPageDown:     true,
+	fyne.KeyHome:         true,
+	fyne.KeyEnd:          true,
+	fyne.KeyF1:           true,
+	fyne.KeyF2:           true,
+	fyne.KeyF3:           true,
+	fyne.KeyF4:           true,
+	fyne.KeyF5:           true,
+	fyne.KeyF6:           true,
+	fyne.KeyF7:           true,
+	fyne.KeyF8:           true,
+	fyne.KeyF9:           true,
+	fyne.KeyF10:          true,
+	fyne.KeyF11:          true,
+	fyne.KeyF12:          true,
+	fyne.KeyEnter:        true,
+	fyne.Key0:            true,
+	fyne.Key1:            true,
+	fyne.Key2:            true,
+	fyne.Key3:            true,
+	fyne.Key4:            true,
+	fyne.Key5:            true,
+	fyne.Key6:            true,
+	fyne.Key7:            true,
+	fyne.Key8:            true,
+	fyne.Key9:            true,
+	fyne.KeyA:            true,
+	fyne.KeyB:            true,
+	fyne.KeyC:            true,
+	fyne.KeyD:            true,
+	fyne.KeyE:            true,
+	fyne.KeyF:            true,
+	fyne.KeyG:            true,
+	fyne.KeyH:            true,
+	fyne.KeyI:            true,
+	fyne.KeyJ:            true,
+	fyne.KeyK:            true,
+	fyne.KeyL:            true,
+	fyne.KeyM:            true,
+	fyne.KeyN:            true,
+	fyne.KeyO:            true,
+	fyne.KeyP:            true,
+	fyne.KeyQ:            true,
+	fyne.KeyR:            true,
+	fyne.KeyS:            true,
+	fyne.KeyT:            true,
+	fyne.KeyU:            true,
+	fyne.KeyV:            true,
+	fyne.KeyW:            true,
+	fyne.KeyX:            true,
+	fyne.KeyY:            true,
+	fyne.KeyZ:            true,
+	fyne.KeySpace:        true,
+	fyne.KeyApostrophe:   true,
+	fyne.KeyComma:        true,
+	fyne.KeyMinus:        true,
+	fyne.KeyPeriod:       true,
+	fyne.KeySlash:        true,
+	fyne.KeyBackslash:    true,
+	fyne.KeyLeftBracket:  true,
+	fyne.KeyRightBracket: true,
+	fyne.KeySemicolon:    true,
+	fyne.KeyEqual:        true,
+	fyne.KeyAsterisk:     true,
+	fyne.KeyPlus:         true,
+	fyne.KeyBackTick:     true,
+}
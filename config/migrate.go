@@ -0,0 +1,52 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// migrations[i] upgrades a config file from version i+1 to version i+2.
+// Files written before the version field existed are version 1; their layout is the same.
+var migrations = []func(raw map[string]interface{}){}
+
+// migrate upgrades raw, a config file at the given version, by every step after it.
+// steps[i] upgrades a file from version i+1 to version i+2.
+func migrate(raw map[string]interface{}, version int, steps []func(raw map[string]interface{})) {
+	for ; version <= len(steps); version++ {
+		steps[version-1](raw)
+	}
+}
+
+// parse reads a config file of any version, migrating it to the current one.
+// Settings missing from the file keep their defaults.
+func parse(data []byte) (*Config, error) {
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if v, ok := raw["Version"].(float64); ok {
+		version = int(v)
+	}
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("config file version %d is newer than this version of the timer supports (%d)", version, CurrentVersion)
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("config file version %d is not a valid version", version)
+	}
+
+	migrate(raw, version, migrations)
+	raw["Version"] = CurrentVersion
+
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := Default()
+	if err := json.Unmarshal(migrated, conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
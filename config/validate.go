@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// ValidationError lists the settings that were invalid.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config, reset to defaults: " + strings.Join(e.Problems, "; ")
+}
+
+const (
+	minRefreshRate = 1
+	maxRefreshRate = 240
+
+	maxDebounce = 2 * time.Second
+)
+
+// Validate checks every setting, resetting any invalid ones to their defaults.
+// It returns a *ValidationError describing what was reset, or nil if nothing was.
+func (c *Config) Validate() error {
+	var problems []string
+	defaults := Default()
+
+	keys := []fyne.KeyName{c.Hotkeys.Split, c.Hotkeys.Pause, c.Hotkeys.Reset, c.Hotkeys.UndoReset}
+	if hotkeyProblem := checkHotkeys(keys); hotkeyProblem != "" {
+		problems = append(problems, hotkeyProblem)
+		c.Hotkeys = defaults.Hotkeys
+	}
+
+	for _, d := range []time.Duration{c.Debounce.Split, c.Debounce.Pause, c.Debounce.Reset, c.Debounce.UndoReset} {
+		if d < 0 || d > maxDebounce {
+			problems = append(problems, "debounce times must be between 0 and 2 seconds")
+			c.Debounce = defaults.Debounce
+			break
+		}
+	}
+
+	if c.RefreshRate < minRefreshRate || c.RefreshRate > maxRefreshRate {
+		problems = append(problems, "refresh rate must be between 1 and 240")
+		c.RefreshRate = defaults.RefreshRate
+	}
+
+	if c.SplitBackups < 0 {
+		problems = append(problems, "split backups cannot be negative")
+		c.SplitBackups = defaults.SplitBackups
+	}
+
+	for path, size := range c.WindowSizes {
+		if size.Width <= 0 || size.Height <= 0 {
+			problems = append(problems, "window size for "+path+" must be positive")
+			delete(c.WindowSizes, path)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{problems}
+}
+
+func checkHotkeys(keys []fyne.KeyName) string {
+	seen := map[fyne.KeyName]bool{}
+	for _, k := range keys {
+		if k == "" {
+			return "every hotkey must be set"
+		}
+		if !keyNames[k] {
+			return "unknown hotkey " + string(k)
+		}
+		if seen[k] {
+			return "hotkeys must all be different keys"
+		}
+		seen[k] = true
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newFunnel charts what fraction of attempts reached each stage, as a bar per stage.
+func newFunnel(names []string, fractions []float64) fyne.CanvasObject {
+	var rows []fyne.CanvasObject
+	for i, name := range names {
+		bar := canvas.NewRectangle(themeColor(theme.ColorNamePrimary))
+		label := widget.NewLabel(fmt.Sprintf("%s (%.0f%%)", name, fractions[i]*100))
+		rows = append(rows, container.NewMax(
+			container.New(&barLayout{fraction: fractions[i]}, bar),
+			label,
+		))
+	}
+	return container.NewVScroll(container.NewVBox(rows...))
+}
+
+// barLayout sizes its only object to a fraction of the available width.
+type barLayout struct {
+	fraction float64
+}
+
+func (l *barLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		o.Move(fyne.NewPos(0, 0))
+		o.Resize(fyne.NewSize(size.Width*float32(l.fraction), size.Height))
+	}
+}
+
+func (l *barLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
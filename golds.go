@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/stats"
+)
+
+// showSumOfBestCleaner opens a window listing golds that look like timing mistakes, so they can be removed.
+func (a *timerApp) showSumOfBestCleaner() {
+	w := a.app.NewWindow("Sum of Best Cleaner")
+	a.fillSumOfBestCleaner(w)
+	w.Resize(fyne.NewSize(720, 400))
+	w.Show()
+}
+
+func (a *timerApp) fillSumOfBestCleaner(w fyne.Window) {
+	suspicious := stats.SuspiciousGolds(a.run)
+	sumOfBest := widget.NewLabel("Sum of best: " + formatting.TimeFormatMilliseconds(a.run.SumOfBest().Milliseconds()) +
+		"\nRemoving a gold only changes the best segment; the time stays in the history and the statistics.")
+	if len(suspicious) == 0 {
+		w.SetContent(container.NewVBox(sumOfBest, widget.NewLabel("No suspicious golds found.")))
+		return
+	}
+
+	var rows []fyne.CanvasObject
+	for _, s := range suspicious {
+		s := s
+		segment := a.run.Segments[s.Segment]
+		gold := segment.GoldHistory[s.Gold]
+
+		date := "before golds were recorded"
+		if !gold.Date.IsZero() {
+			date = gold.Date.Format("2006-01-02 15:04")
+		}
+		label := widget.NewLabel(fmt.Sprintf("%s: %s, set %s\n%s", segment.Name,
+			formatting.TimeFormatMilliseconds(gold.Time.Milliseconds()), date, s.Reason))
+
+		remove := widget.NewButton("Remove", func() {
+			dialog.ShowConfirm("Remove Gold", "Remove this gold? The segment's best time will fall back to the fastest gold left.",
+				func(ok bool) {
+					if !ok {
+						return
+					}
+					if err := a.run.RemoveGold(s.Segment, s.Gold); err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					a.saveRun()
+					a.rebuildLayout()
+					a.fillSumOfBestCleaner(w)
+				}, w)
+		})
+		rows = append(rows, container.NewBorder(nil, nil, nil, remove, label))
+	}
+
+	w.SetContent(container.NewBorder(sumOfBest, nil, nil, nil, container.NewVScroll(container.NewVBox(rows...))))
+}
+
+// goldStats is a table of every segment's gold history.
+func (a *timerApp) goldStats() fyne.CanvasObject {
+	var rows [][]string
+	for _, s := range a.run.Segments {
+		for _, g := range s.GoldHistory {
+			date := "-"
+			if !g.Date.IsZero() {
+				date = g.Date.Format("2006-01-02 15:04")
+			}
+			rows = append(rows, []string{s.Name, formatting.TimeFormatMilliseconds(g.Time.Milliseconds()), date})
+		}
+	}
+	return newStatsTable([]string{"Segment", "Gold", "Date"}, rows)
+}
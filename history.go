@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/stats"
+)
+
+var attemptSorts = map[string]stats.AttemptSort{
+	"Date":       stats.SortByDate,
+	"Duration":   stats.SortByDuration,
+	"Reached":    stats.SortByReached,
+	"Final Time": stats.SortByFinalTime,
+}
+
+// attemptPeriods are the choices for how far back the history goes; 0 is every attempt.
+var attemptPeriods = map[string]time.Duration{
+	"Any time":      0,
+	"Last 7 days":   7 * 24 * time.Hour,
+	"Last 30 days":  30 * 24 * time.Hour,
+	"Last 365 days": 365 * 24 * time.Hour,
+}
+
+// showHistory opens a window for browsing the loaded run's past attempts.
+func (a *timerApp) showHistory() {
+	w := a.app.NewWindow("Run History")
+
+	sortBy := stats.SortByDate
+	descending := true
+	filter := stats.AttemptFilter{}
+	var shown []stats.AttemptSummary
+
+	detail := container.NewMax(widget.NewLabel("Select an attempt to see its splits."))
+	list := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(a.attemptLabel(shown[id]))
+		},
+	)
+
+	refresh := func() {
+		shown = stats.FilterAttempts(stats.Attempts(a.run), filter)
+		stats.SortAttempts(shown, sortBy, descending)
+		list.UnselectAll()
+		list.Refresh()
+		detail.Objects = []fyne.CanvasObject{widget.NewLabel("Select an attempt to see its splits.")}
+		detail.Refresh()
+	}
+
+	list.OnSelected = func(id widget.ListItemID) {
+		attempt := shown[id]
+		remove := widget.NewButton("Delete Attempt", func() {
+			dialog.ShowConfirm("Delete Attempt", "Delete this attempt from the history? This can't be undone.", func(ok bool) {
+				if !ok {
+					return
+				}
+				// the history may have changed since the attempt was selected, e.g. by a reset
+				idx := a.run.FindAttempt(attempt.Attempt)
+				if idx < 0 {
+					dialog.ShowError(fmt.Errorf("the attempt is no longer in the history"), w)
+					refresh()
+					return
+				}
+				// deleting also drops any reset waiting to be undone, as the history it would restore has changed
+				if err := a.run.DeleteAttempt(idx); err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				a.saveRun()
+				a.rebuildLayout()
+				refresh()
+			}, w)
+		})
+		detail.Objects = []fyne.CanvasObject{container.NewBorder(nil, remove, nil, nil, a.attemptSplits(attempt))}
+		detail.Refresh()
+	}
+
+	sortSelect := widget.NewSelect([]string{"Date", "Duration", "Reached", "Final Time"}, func(name string) {
+		sortBy = attemptSorts[name]
+		refresh()
+	})
+	sortSelect.SetSelected("Date")
+
+	descendingCheck := widget.NewCheck("Descending", func(on bool) {
+		descending = on
+		refresh()
+	})
+	descendingCheck.SetChecked(true)
+
+	periodSelect := widget.NewSelect([]string{"Any time", "Last 7 days", "Last 30 days", "Last 365 days"}, func(name string) {
+		filter.Since = time.Time{}
+		if period := attemptPeriods[name]; period > 0 {
+			filter.Since = time.Now().Add(-period)
+		}
+		refresh()
+	})
+	periodSelect.SetSelected("Any time")
+
+	filters := container.NewHBox(
+		periodSelect,
+		widget.NewCheck("Finished only", func(on bool) { filter.FinishedOnly = on; refresh() }),
+		widget.NewCheck("PBs only", func(on bool) { filter.PBsOnly = on; refresh() }),
+		widget.NewCheck("Golds only", func(on bool) { filter.GoldsOnly = on; refresh() }),
+	)
+
+	toolbar := container.NewVBox(container.NewHBox(widget.NewLabel("Sort by"), sortSelect, descendingCheck), filters)
+	w.SetContent(container.NewBorder(toolbar, nil, nil, nil, container.NewHSplit(list, detail)))
+	refresh()
+	w.Resize(fyne.NewSize(800, 500))
+	w.Show()
+}
+
+func (a *timerApp) attemptLabel(s stats.AttemptSummary) string {
+	date := "unknown date"
+	if !s.Attempt.Began.IsZero() {
+		date = s.Attempt.Began.Format("2006-01-02 15:04")
+	}
+
+	result := fmt.Sprintf("reset at %d/%d", s.Attempt.Reached(), len(a.run.Segments))
+	if s.Finished {
+		result = formatting.TimeFormatMilliseconds(s.Final.Milliseconds())
+	}
+
+	out := fmt.Sprintf("%s  %s  (%s)", date, result, s.Duration.Round(time.Second))
+	if s.PB {
+		out += "  PB"
+	}
+	if s.Golds > 0 {
+		out += fmt.Sprintf("  %d gold", s.Golds)
+		if s.Golds > 1 {
+			out += "s"
+		}
+	}
+	return out
+}
+
+// attemptSplits is a table of an attempt's split and segment times.
+func (a *timerApp) attemptSplits(s stats.AttemptSummary) fyne.CanvasObject {
+	var rows [][]string
+	for i, at := range s.Attempt.Splits {
+		if i >= len(a.run.Segments) {
+			break // the run has lost segments since the attempt
+		}
+		segment, _ := s.Attempt.SegmentTime(i)
+		rows = append(rows, []string{
+			a.run.Segments[i].Name,
+			formatting.TimeFormatMilliseconds(at.Milliseconds()),
+			formatting.TimeFormatMilliseconds(segment.Milliseconds()),
+		})
+	}
+	return newStatsTable([]string{"Split", "Time", "Segment"}, rows)
+}
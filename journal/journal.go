@@ -0,0 +1,156 @@
+// Package journal keeps the state of the attempt in progress on disk,
+// so that it can be resumed if the app closes part way through.
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/adrg/xdg"
+
+	"speedruntimer/atomicfile"
+	"speedruntimer/timing/timer"
+)
+
+const journal_path = "speedruntimer/journal.json"
+
+// Entry is the attempt in progress, and which split file it is for.
+type Entry struct {
+	SplitFile string
+	State     timer.State
+}
+
+// Journal is a timer.Journal writing to disk. Only one attempt is kept at a time.
+//
+// States are written in the background, so that recording one never holds up the timer;
+// if several are recorded before the last is written, only the newest is.
+type Journal struct {
+	splitFile string
+	onError   func(error)
+
+	mu      sync.Mutex
+	pending *timer.State
+	wake    chan struct{}
+	closed  chan struct{}
+	done    chan struct{}
+}
+
+// New returns a journal for attempts at the run in splitFile.
+// onError is called, from the journal's own goroutine, whenever the state can't be written.
+func New(splitFile string, onError func(error)) *Journal {
+	j := &Journal{
+		splitFile: splitFile,
+		onError:   onError,
+		wake:      make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go j.run()
+	return j
+}
+
+// Record queues the state to be written to disk. Once there is no attempt in progress, the journal is cleared instead.
+func (j *Journal) Record(s timer.State) {
+	j.mu.Lock()
+	j.pending = &s
+	j.mu.Unlock()
+
+	select {
+	case j.wake <- struct{}{}:
+	default: // already woken, and it will pick up the newest state
+	}
+}
+
+// Close writes any state still waiting to be written, then stops the journal. Later states are not recorded.
+func (j *Journal) Close() {
+	select {
+	case <-j.closed:
+	default:
+		close(j.closed)
+	}
+	<-j.done
+}
+
+func (j *Journal) run() {
+	defer close(j.done)
+	for {
+		select {
+		case <-j.wake:
+			j.flush()
+		case <-j.closed:
+			j.flush()
+			return
+		}
+	}
+}
+
+func (j *Journal) flush() {
+	j.mu.Lock()
+	s := j.pending
+	j.pending = nil
+	j.mu.Unlock()
+	if s == nil {
+		return
+	}
+
+	var err error
+	if s.Idle() {
+		err = Clear()
+	} else {
+		err = write(Entry{j.splitFile, *s})
+	}
+
+	if err != nil && j.onError != nil {
+		j.onError(err)
+	}
+}
+
+func write(e Entry) error {
+	path, err := xdg.StateFile(journal_path)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(path, data, 0644)
+}
+
+// Load returns the attempt that was in progress, or nil if there was none.
+func Load() (*Entry, error) {
+	path, err := xdg.StateFile(journal_path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Entry{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func Clear() error {
+	path, err := xdg.StateFile(journal_path)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,43 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestJournal(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	defer xdg.Reload()
+
+	e, err := Load()
+	assert.Nil(t, err)
+	assert.Nil(t, e, "there is nothing to load before anything is recorded")
+
+	var errs []error
+	j := New("run.json", func(err error) { errs = append(errs, err) })
+
+	state := timer.State{Start: time.Now().Round(0), Segment: 1, Splits: []time.Duration{time.Minute}}
+	j.Record(state)
+	j.Close()
+	e, err = Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "run.json", e.SplitFile)
+	assert.True(t, state.Start.Equal(e.State.Start))
+	assert.Equal(t, state.Splits, e.State.Splits, "the recorded state is loaded")
+
+	j = New("run.json", func(err error) { errs = append(errs, err) })
+	j.Record(state)
+	j.Record(timer.State{})
+	j.Close()
+	e, err = Load()
+	assert.Nil(t, err)
+	assert.Nil(t, e, "recording an idle timer clears the journal, and only the newest state counts")
+
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,175 @@
+package layout
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+
+	"speedruntimer/style"
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/timer"
+)
+
+// clock shows the total time of the current attempt, and marks when it is paused.
+type clock struct {
+	time    timer.Timer
+	text    *canvas.Text
+	paused  *canvas.Text
+	content *fyne.Container
+}
+
+func newClock(t timer.Timer, _ *timer.Run, raw json.RawMessage) (Component, error) {
+	if err := decodeSettings(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+
+	text := canvas.NewText(formatting.TimeFormatMilliseconds(0), themeColor(theme.ColorNameForeground))
+	text.TextSize = themeSize(style.SizeNameTimer)
+	text.TextStyle.Monospace = true
+	text.Alignment = fyne.TextAlignTrailing
+
+	paused := canvas.NewText("paused", themeColor(theme.ColorNameDisabled))
+	paused.Hide()
+
+	return &clock{time: t, text: text, paused: paused, content: container.NewBorder(nil, nil, paused, nil, text)}, nil
+}
+
+func (c *clock) Object() fyne.CanvasObject {
+	return c.content
+}
+
+func (c *clock) Update() {
+	c.Tick()
+}
+
+func (c *clock) Tick() {
+	if remaining := c.time.CountdownRemaining(); remaining > 0 {
+		c.text.Text = countdownText(remaining)
+	} else {
+		c.text.Text = c.time.String()
+	}
+	c.text.Color = themeColor(theme.ColorNameForeground)
+	if len(c.time.Firings()) > 0 {
+		// a dead run rule has fired
+		c.text.Color = themeColor(style.ColorNameBehind)
+	}
+	c.text.Refresh()
+
+	if c.time.Paused() {
+		c.paused.Show()
+	} else {
+		c.paused.Hide()
+	}
+}
+
+// countdownText shows the whole seconds left before the timer starts, i.e. 3, 2, 1.
+func countdownText(remaining time.Duration) string {
+	return strconv.FormatInt(int64((remaining+time.Second-1)/time.Second), 10)
+}
+
+type segmentClockSettings struct {
+	// ShowComparison adds the current segment's time in the PB run.
+	ShowComparison bool
+	// ShowBestSegment adds the current segment's best time.
+	ShowBestSegment bool
+}
+
+// segmentClock shows the time spent in the current segment,
+// along with what it is being compared against.
+type segmentClock struct {
+	time timer.Timer
+	run  *timer.Run
+
+	text       *canvas.Text
+	comparison *infoRow
+	best       *infoRow
+	content    *fyne.Container
+}
+
+func newSegmentClock(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := segmentClockSettings{ShowComparison: true, ShowBestSegment: true}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	text := canvas.NewText(formatting.TimeFormatMilliseconds(0), themeColor(theme.ColorNameForeground))
+	text.TextSize = themeSize(style.SizeNameSegmentTimer)
+	text.TextStyle.Monospace = true
+	text.Alignment = fyne.TextAlignTrailing
+
+	ret := &segmentClock{time: t, run: run, text: text, content: container.NewVBox(text)}
+	if settings.ShowComparison {
+		ret.comparison = newInfoRow("PB")
+		ret.content.Add(ret.comparison.Object())
+	}
+	if settings.ShowBestSegment {
+		ret.best = newInfoRow("Best")
+		ret.content.Add(ret.best.Object())
+	}
+
+	ret.Update()
+	return ret, nil
+}
+
+func (c *segmentClock) Object() fyne.CanvasObject {
+	return c.content
+}
+
+func (c *segmentClock) Update() {
+	c.Tick()
+
+	idx := c.time.CurrentSegment()
+	if idx >= len(c.run.Segments) {
+		// run is finished; keep showing the last segment
+		idx = len(c.run.Segments) - 1
+	}
+
+	if c.comparison != nil {
+		c.comparison.setValue(formatting.TimeFormatMilliseconds(c.run.PBSegment(idx).Milliseconds()))
+	}
+	if c.best != nil {
+		c.best.setValue(formatting.TimeFormatMilliseconds(c.run.Segments[idx].BestSegment.Milliseconds()))
+	}
+}
+
+func (c *segmentClock) Tick() {
+	c.text.Text = formatting.TimeFormatMilliseconds(c.time.SegmentElapsed().Milliseconds())
+	c.text.Refresh()
+}
+
+// detailedClock shows the total time with the segment time and its comparisons underneath.
+type detailedClock struct {
+	main    *clock
+	segment *segmentClock
+}
+
+func newDetailedClock(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	main, err := newClock(t, run, nil)
+	if err != nil {
+		return nil, err
+	}
+	segment, err := newSegmentClock(t, run, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &detailedClock{main.(*clock), segment.(*segmentClock)}, nil
+}
+
+func (c *detailedClock) Object() fyne.CanvasObject {
+	return container.NewVBox(c.main.Object(), c.segment.Object())
+}
+
+func (c *detailedClock) Update() {
+	c.main.Update()
+	c.segment.Update()
+}
+
+func (c *detailedClock) Tick() {
+	c.main.Tick()
+	c.segment.Tick()
+}
@@ -0,0 +1,61 @@
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+
+	"speedruntimer/timing/timer"
+)
+
+// Component is a single element of the timer window, e.g. the title or the clock.
+type Component interface {
+	// Object returns what is drawn for the component.
+	Object() fyne.CanvasObject
+	// Update redraws the component after the timer changes state.
+	Update()
+}
+
+// Ticker is implemented by components that need to be redrawn every frame,
+// e.g. anything showing the running time.
+type Ticker interface {
+	Tick()
+}
+
+type componentConstructor func(t timer.Timer, run *timer.Run, settings json.RawMessage) (Component, error)
+
+var componentTypes = map[string]componentConstructor{
+	"title":            newTitle,
+	"splits":           newSplits,
+	"timer":            newClock,
+	"segmenttimer":     newSegmentClock,
+	"detailedtimer":    newDetailedClock,
+	"previoussegment":  newPreviousSegment,
+	"sumofbest":        newSumOfBest,
+	"possibletimesave": newPossibleTimeSave,
+	"attempts":         newAttemptCounter,
+	"text":             newText,
+	"separator":        newSeparator,
+	"spacer":           newSpacer,
+	"graph":            newGraph,
+	"grades":           newGradeSummary,
+	"pbchance":         newPBChance,
+}
+
+func newComponent(c ComponentConfig, t timer.Timer, run *timer.Run) (Component, error) {
+	constructor, ok := componentTypes[c.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown layout component type %q", c.Type)
+	}
+	return constructor(t, run, c.Settings)
+}
+
+// decodeSettings fills in a component's settings struct, leaving
+// the defaults in place for anything the layout file leaves out.
+func decodeSettings(raw json.RawMessage, into interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, into)
+}
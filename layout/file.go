@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// File describes which components are shown, in what order, and with which settings.
+// It is stored separately from the split file so one layout can be used for many runs.
+type File struct {
+	Components []ComponentConfig
+}
+
+type ComponentConfig struct {
+	Type     string
+	Settings json.RawMessage `json:",omitempty"`
+}
+
+// DefaultFile returns the layout used when none has been loaded.
+func DefaultFile() *File {
+	return &File{
+		Components: []ComponentConfig{
+			{Type: "title"},
+			{Type: "splits"},
+			{Type: "spacer"},
+			{Type: "timer"},
+		},
+	}
+}
+
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{}
+	err = json.Unmarshal(data, f)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"encoding/json"
+
+	"speedruntimer/timing/stats"
+	"speedruntimer/timing/timer"
+)
+
+type gradeSummarySettings struct {
+	infoSettings
+	// Adjust grades each segment relative to how the attempt went before it.
+	Adjust bool
+}
+
+// gradeSummary counts the grades of the attempt's segments once it is finished.
+type gradeSummary struct {
+	*infoRow
+	settings gradeSummarySettings
+	time     timer.Timer
+	run      *timer.Run
+}
+
+func newGradeSummary(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := gradeSummarySettings{infoSettings: infoSettings{Label: "Grades"}}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	ret := &gradeSummary{newInfoRow(settings.Label), settings, t, run}
+	ret.Update()
+	return ret, nil
+}
+
+func (g *gradeSummary) Update() {
+	if g.time.CurrentSegment() < len(g.run.Segments) {
+		g.setValue("-")
+		return
+	}
+
+	grades := stats.GradeSegments(g.run, stats.AttemptSegments(g.time, g.run), g.settings.Adjust)
+	g.setValue(stats.GradeSummary(grades))
+}
@@ -0,0 +1,183 @@
+package layout
+
+import (
+	"encoding/json"
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/plot"
+	"speedruntimer/style"
+	"speedruntimer/timing/timer"
+)
+
+type graphSettings struct {
+	Height float32
+}
+
+// graphPoint is the delta at one split of the current run.
+type graphPoint struct {
+	x     float32 // how far through the run the split is, between 0 and 1
+	delta time.Duration
+	gold  bool
+}
+
+// graphPoints returns a point for every split completed so far in the run,
+// skipping any split that has no PB time to compare against.
+func graphPoints(run *timer.Run, current int) (out []graphPoint) {
+	for idx := 0; idx < current && idx < len(run.Segments); idx++ {
+		s := run.Segments[idx]
+		if s.PBTime == 0 {
+			continue
+		}
+		out = append(out, graphPoint{
+			x:     float32(idx+1) / float32(len(run.Segments)),
+			delta: s.ActiveRunTime - s.PBTime,
+			gold:  s.IsGold(),
+		})
+	}
+	return out
+}
+
+// graph plots how far ahead or behind the PB run the current run has been at each split.
+// Being ahead is drawn above the zero line.
+type graph struct {
+	time timer.Timer
+	run  *timer.Run
+
+	widget *graphWidget
+}
+
+func newGraph(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := graphSettings{Height: 80}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	ret := &graph{time: t, run: run, widget: &graphWidget{height: settings.Height}}
+	ret.widget.ExtendBaseWidget(ret.widget)
+	ret.Update()
+	return ret, nil
+}
+
+func (g *graph) Object() fyne.CanvasObject {
+	return g.widget
+}
+
+func (g *graph) Update() {
+	g.widget.points = graphPoints(g.run, g.time.CurrentSegment())
+	g.widget.Refresh()
+}
+
+type graphWidget struct {
+	widget.BaseWidget
+
+	height float32
+	points []graphPoint
+}
+
+func (g *graphWidget) CreateRenderer() fyne.WidgetRenderer {
+	r := &graphRenderer{graph: g, zero: canvas.NewLine(themeColor(theme.ColorNameSeparator))}
+	r.Refresh()
+	return r
+}
+
+type graphRenderer struct {
+	graph *graphWidget
+
+	// mu guards everything below, as Refresh runs on the ticker's goroutine while Layout can run on the driver's.
+	mu sync.Mutex
+	// points are the graph's points as of the last Refresh, which line and markers were built for.
+	points  []graphPoint
+	zero    *canvas.Line
+	line    *plot.Line
+	markers []*canvas.Circle
+	objects []fyne.CanvasObject
+}
+
+const graphMarkerRadius = 3
+
+func (r *graphRenderer) Layout(size fyne.Size) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.layout(size)
+}
+
+func (r *graphRenderer) layout(size fyne.Size) {
+	middle := size.Height / 2
+	r.zero.Position1 = fyne.NewPos(0, middle)
+	r.zero.Position2 = fyne.NewPos(size.Width, middle)
+
+	scale := time.Second
+	for _, p := range r.points {
+		if p.delta > scale {
+			scale = p.delta
+		} else if -p.delta > scale {
+			scale = -p.delta
+		}
+	}
+
+	position := func(p graphPoint) fyne.Position {
+		// leave room for the markers at the edges
+		height := middle - graphMarkerRadius
+		return fyne.NewPos(p.x*size.Width, middle+float32(p.delta)/float32(scale)*height)
+	}
+
+	// the line starts level with the PB, at the start of the run
+	positions := []fyne.Position{fyne.NewPos(0, middle)}
+	for i, p := range r.points {
+		pos := position(p)
+		positions = append(positions, pos)
+
+		if r.markers[i] != nil {
+			r.markers[i].Position1 = pos.SubtractXY(graphMarkerRadius, graphMarkerRadius)
+			r.markers[i].Position2 = pos.AddXY(graphMarkerRadius, graphMarkerRadius)
+		}
+	}
+	r.line.Place(positions)
+}
+
+func (r *graphRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(0, r.graph.height)
+}
+
+// Refresh rebuilds the line and markers, since the number of points changes as the run goes on.
+func (r *graphRenderer) Refresh() {
+	r.mu.Lock()
+	r.points = append([]graphPoint(nil), r.graph.points...)
+	r.zero.StrokeColor = themeColor(theme.ColorNameSeparator)
+	r.markers = nil
+
+	var colors []color.Color
+	for _, p := range r.points {
+		colors = append(colors, themeColor(deltaColor(p.delta, false)))
+	}
+	r.line = plot.NewLine(colors...)
+	r.objects = append([]fyne.CanvasObject{r.zero}, r.line.Objects()...)
+
+	for _, p := range r.points {
+		var marker *canvas.Circle
+		if p.gold {
+			marker = canvas.NewCircle(themeColor(style.ColorNameGold))
+			r.objects = append(r.objects, marker)
+		}
+		r.markers = append(r.markers, marker)
+	}
+
+	r.layout(r.graph.Size())
+	r.mu.Unlock()
+	canvas.Refresh(r.graph)
+}
+
+func (r *graphRenderer) Objects() []fyne.CanvasObject {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.objects
+}
+
+func (r *graphRenderer) Destroy() {}
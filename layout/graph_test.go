@@ -0,0 +1,46 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestGraphPoints(t *testing.T) {
+	run := &timer.Run{Segments: []*timer.Split{
+		{Name: "1", PBTime: 10 * time.Second, BestSegment: 10 * time.Second},
+		{Name: "2"},
+		{Name: "3", PBTime: 40 * time.Second, BestSegment: 10 * time.Second},
+		{Name: "4", PBTime: 50 * time.Second, BestSegment: 10 * time.Second},
+	}}
+	run.Segments[0].Split(12*time.Second, 0)
+	run.Segments[1].Split(20*time.Second, 12*time.Second)
+	run.Segments[2].Split(29*time.Second, 20*time.Second)
+
+	assert.Equal(t, []graphPoint{
+		{0.25, 2 * time.Second, false},
+		{0.75, -11 * time.Second, true},
+	}, graphPoints(run, 3), "splits without a PB time are skipped, and golds are marked")
+
+	assert.Empty(t, graphPoints(run, 0), "nothing is plotted before the first split")
+}
+
+func TestGraphLayout(t *testing.T) {
+	test.NewApp()
+	w := &graphWidget{height: 80, points: []graphPoint{{0.5, time.Second, false}, {1, -time.Second, true}}}
+	w.ExtendBaseWidget(w)
+	r := w.CreateRenderer()
+
+	w.points = []graphPoint{{0.25, time.Second, false}, {0.5, time.Second, false}, {1, 2 * time.Second, true}}
+	assert.NotPanics(t, func() { r.Layout(fyne.NewSize(200, 80)) }, "a layout before the refresh uses the points the lines were built for")
+
+	r.Refresh()
+	assert.Len(t, r.Objects(), 5, "a refresh rebuilds the lines and markers for the new points")
+	w.points = w.points[:1]
+	assert.NotPanics(t, func() { r.Layout(fyne.NewSize(200, 80)) })
+}
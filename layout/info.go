@@ -0,0 +1,265 @@
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/style"
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/timer"
+)
+
+// infoRow is a single line with a description on the left and a value on the right.
+// Most of the simpler components are one of these.
+type infoRow struct {
+	name  *widget.Label
+	value *widget.RichText
+	row   *fyne.Container
+}
+
+func newInfoRow(name string) *infoRow {
+	ret := &infoRow{name: widget.NewLabel(name), value: widget.NewRichText(&widget.TextSegment{Style: widget.RichTextStyleInline})}
+	ret.row = container.NewHBox(ret.name, layout.NewSpacer(), ret.value)
+	return ret
+}
+
+func (r *infoRow) Object() fyne.CanvasObject {
+	return r.row
+}
+
+func (r *infoRow) setValue(text string) {
+	r.setColoredValue(text, theme.ColorNameForeground)
+}
+
+func (r *infoRow) setColoredValue(text string, color fyne.ThemeColorName) {
+	segment := r.value.Segments[0].(*widget.TextSegment)
+	segment.Text = text
+	segment.Style.ColorName = color
+	r.value.Refresh()
+}
+
+type infoSettings struct {
+	Label string
+}
+
+func decodeInfoSettings(raw json.RawMessage, defaultLabel string) (infoSettings, error) {
+	settings := infoSettings{Label: defaultLabel}
+	err := decodeSettings(raw, &settings)
+	return settings, err
+}
+
+// lastCompletedSegment returns the index of the most recently split segment, or -1 if there is none.
+func lastCompletedSegment(t timer.Timer, run *timer.Run) int {
+	idx := t.CurrentSegment() - 1
+	if idx >= len(run.Segments) {
+		idx = len(run.Segments) - 1
+	}
+	return idx
+}
+
+// previousSegment shows how the last completed segment compared to the same segment in the PB run.
+// While the current segment is already slower than in the PB run, it shows that instead.
+type previousSegment struct {
+	*infoRow
+	settings infoSettings
+	time     timer.Timer
+	run      *timer.Run
+
+	// mu guards what Update publishes for Tick, which runs on the ticker's goroutine.
+	mu sync.Mutex
+	// comparison is the current segment's time in the PB run, or 0 if there is nothing to compare against.
+	comparison time.Duration
+	live       bool
+}
+
+func newPreviousSegment(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings, err := decodeInfoSettings(raw, "Previous Segment")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &previousSegment{infoRow: newInfoRow(settings.Label), settings: settings, time: t, run: run}
+	ret.Update()
+	return ret, nil
+}
+
+func (p *previousSegment) Update() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.live {
+		p.live = false
+		p.name.SetText(p.settings.Label)
+	}
+
+	p.comparison = 0
+	if current := p.time.CurrentSegment(); p.time.Running() && current < len(p.run.Segments) && p.run.Segments[current].PBTime != 0 {
+		p.comparison = p.run.PBSegment(current)
+	}
+
+	idx := lastCompletedSegment(p.time, p.run)
+	if idx < 0 || p.run.Segments[idx].PBTime == 0 {
+		p.setValue("-")
+		return
+	}
+
+	split := p.run.Segments[idx]
+	delta := split.ActiveSegment - p.run.PBSegment(idx)
+	p.setColoredValue(formatting.DeltaFormatMilliseconds(delta.Milliseconds()), deltaColor(delta, split.IsGold()))
+}
+
+func (p *previousSegment) Tick() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.comparison == 0 || !p.time.Running() {
+		return
+	}
+
+	behind := p.time.SegmentElapsed() - p.comparison
+	if behind <= 0 {
+		return
+	}
+
+	if !p.live {
+		p.live = true
+		p.name.SetText("Live Segment")
+	}
+	p.setColoredValue(formatting.DeltaFormatMilliseconds(behind.Milliseconds()), style.ColorNameBehind)
+}
+
+type sumOfBest struct {
+	*infoRow
+	run *timer.Run
+}
+
+func newSumOfBest(_ timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings, err := decodeInfoSettings(raw, "Sum of Best")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &sumOfBest{newInfoRow(settings.Label), run}
+	ret.Update()
+	return ret, nil
+}
+
+func (s *sumOfBest) Update() {
+	s.setValue(formatting.TimeFormatMilliseconds(s.run.SumOfBest().Milliseconds()))
+}
+
+type possibleTimeSaveSettings struct {
+	infoSettings
+	// Total shows the time save over the whole run instead of the current segment.
+	Total bool
+}
+
+// possibleTimeSave shows how much faster the PB run could have been with best segments.
+type possibleTimeSave struct {
+	*infoRow
+	settings possibleTimeSaveSettings
+	time     timer.Timer
+	run      *timer.Run
+}
+
+func newPossibleTimeSave(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := possibleTimeSaveSettings{infoSettings: infoSettings{Label: "Possible Time Save"}}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	ret := &possibleTimeSave{newInfoRow(settings.Label), settings, t, run}
+	ret.Update()
+	return ret, nil
+}
+
+func (p *possibleTimeSave) Update() {
+	if p.settings.Total {
+		p.setValue(formatting.TimeFormatMilliseconds((p.run.PBTime() - p.run.SumOfBest()).Milliseconds()))
+		return
+	}
+
+	idx := p.time.CurrentSegment()
+	if idx >= len(p.run.Segments) {
+		p.setValue("-")
+		return
+	}
+	save := p.run.PBSegment(idx) - p.run.Segments[idx].BestSegment
+	p.setValue(formatting.TimeFormatMilliseconds(save.Milliseconds()))
+}
+
+type attemptCounter struct {
+	*infoRow
+	run *timer.Run
+}
+
+func newAttemptCounter(_ timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings, err := decodeInfoSettings(raw, "Attempts")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &attemptCounter{newInfoRow(settings.Label), run}
+	ret.Update()
+	return ret, nil
+}
+
+func (a *attemptCounter) Update() {
+	a.setValue(fmt.Sprint(a.run.Attempts))
+}
+
+type textSettings struct {
+	Left  string
+	Right string
+}
+
+// text shows fixed text, e.g. a note about the route or the runner's name.
+type text struct {
+	*infoRow
+}
+
+func newText(_ timer.Timer, _ *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := textSettings{}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	ret := &text{newInfoRow(settings.Left)}
+	ret.setValue(settings.Right)
+	return ret, nil
+}
+
+func (t *text) Update() {}
+
+type separator struct {
+	object fyne.CanvasObject
+}
+
+func newSeparator(_ timer.Timer, _ *timer.Run, raw json.RawMessage) (Component, error) {
+	if err := decodeSettings(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	return &separator{widget.NewSeparator()}, nil
+}
+
+// newSpacer takes up any vertical space left over, e.g. to keep the timer at the bottom of the window.
+func newSpacer(_ timer.Timer, _ *timer.Run, raw json.RawMessage) (Component, error) {
+	if err := decodeSettings(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	return &separator{layout.NewSpacer()}, nil
+}
+
+func (s *separator) Object() fyne.CanvasObject {
+	return s.object
+}
+
+func (s *separator) Update() {}
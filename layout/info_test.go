@@ -0,0 +1,28 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestPreviousSegmentComparison(t *testing.T) {
+	test.NewApp()
+	run := &timer.Run{Segments: []*timer.Split{{PBTime: 10 * time.Second}, {PBTime: 25 * time.Second}}}
+	tm, _ := timer.New(run)
+	c, _ := newPreviousSegment(tm, run, nil)
+	p := c.(*previousSegment)
+	assert.Equal(t, time.Duration(0), p.comparison, "nothing is compared while idle")
+
+	tm.Split() // starts the timer
+	p.Update()
+	assert.Equal(t, 10*time.Second, p.comparison, "Update publishes the current segment's PB time for Tick")
+
+	tm.Split()
+	p.Update()
+	assert.Equal(t, 15*time.Second, p.comparison, "the comparison is of the segment, not the run so far")
+}
@@ -1,136 +1,198 @@
 package layout
 
 import (
-	"image/color"
 	"time"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/layout"
-
-	//"fyne.io/fyne/v2/layout"
-	"fyne.io/fyne/v2/widget"
+	"github.com/rs/zerolog/log"
 
+	"speedruntimer/timing/formatting"
 	"speedruntimer/timing/timer"
 )
 
 type TimerLayout struct {
-	labels     *labels
+	components []Component
+	run        *timer.Run
 	currentRun timer.Timer
-}
+	options    Options
+	window     fyne.Window
+	stop       chan struct{}
 
-type labels struct {
-	game       *canvas.Text
-	category   *canvas.Text
-	splitNames []*widget.Label
-	deltas     []*widget.Label
-	splits     []*widget.Label
-	clock      *canvas.Text
+	// lastPress holds when each hotkey was last acted on, for debouncing
+	lastPress map[fyne.KeyName]time.Time
 }
 
-func NewTimerLayout(run *timer.Run) *TimerLayout {
-	time, _ := timer.New(run) // TODO: potential error left unhandled
+// Options are the settings for a TimerLayout that belong to the app rather than the layout file.
+type Options struct {
+	SplitKey, PauseKey, ResetKey, UndoResetKey fyne.KeyName
+	// Debounce is how soon after each hotkey is acted on that presses of it are ignored.
+	Debounce map[fyne.KeyName]time.Duration
+	// RefreshRate is how many times per second the clocks are redrawn.
+	RefreshRate int
+	// OnReset is called after the timer is reset, if set.
+	OnReset func()
+	// Journal records the timer's state as it changes, if set.
+	Journal timer.Journal
+}
 
-	var namelabels, deltalabels, splitlabels []*widget.Label
-	for _, s := range run.Segments {
-		namelabels = append(namelabels, widget.NewLabel(s.Name))
-		deltalabels = append(deltalabels, widget.NewLabel(s.Delta()))
-		splitlabels = append(splitlabels, widget.NewLabel(s.String()))
+func NewTimerLayout(run *timer.Run, file *File, options Options) (*TimerLayout, error) {
+	currentRun, err := timer.New(run)
+	if err != nil {
+		return nil, err
 	}
 
-	// Special case: no run loaded
-	if len(run.Segments) == 1 && run.Segments[0].Name == "" {
-		namelabels = []*widget.Label{}
-		deltalabels = []*widget.Label{}
-		splitlabels = []*widget.Label{}
+	if options.Journal != nil {
+		currentRun.SetJournal(options.Journal)
 	}
 
-	ret := &TimerLayout{
-		&labels{
-			canvas.NewText(run.GameName, color.White),
-			canvas.NewText(run.Category, color.White),
-			namelabels,
-			deltalabels,
-			splitlabels,
-			canvas.NewText("0:00.000", color.White),
-		},
-		time,
+	ret := &TimerLayout{run: run, currentRun: currentRun, options: options, lastPress: map[fyne.KeyName]time.Time{}}
+	for _, c := range file.Components {
+		component, err := newComponent(c, currentRun, run)
+		if err != nil {
+			return nil, err
+		}
+		ret.components = append(ret.components, component)
 	}
 
-	ret.labels.game.TextSize = 32
-	ret.labels.game.Alignment = fyne.TextAlignCenter
-
-	ret.labels.category.TextSize = 24
-	ret.labels.category.Alignment = fyne.TextAlignCenter
-
-	ret.labels.clock.TextSize = 32
-	ret.labels.clock.Alignment = fyne.TextAlignTrailing
-
-	return ret
+	return ret, nil
 }
 
 func (t *TimerLayout) handleKeyInput(k *fyne.KeyEvent) {
-	if k.Name == fyne.KeySpace {
+	if t.bouncing(k.Name) {
+		return
+	}
+
+	if k.Name == t.options.PauseKey {
 		t.currentRun.Pause()
 	}
 
-	if k.Name == fyne.KeyBackspace {
-		t.currentRun.Stop()
+	if k.Name == t.options.ResetKey {
+		// the first press ends the attempt, the second resets it
+		if t.currentRun.Stopped() {
+			t.reset()
+		} else {
+			t.currentRun.Stop()
+		}
 	}
 
-	if k.Name == fyne.KeyReturn {
+	if k.Name == t.options.UndoResetKey {
+		// the reset was saved, so the attempt coming back has to be too
+		if t.currentRun.UndoReset() && t.options.OnReset != nil {
+			t.options.OnReset()
+		}
+	}
+
+	if k.Name == t.options.SplitKey {
+		wasRunning := t.currentRun.Running() && t.currentRun.CountdownRemaining() == 0
+		segment := t.currentRun.CurrentSegment()
+		fired := len(t.currentRun.Firings())
 		t.currentRun.Split()
+		if wasRunning && t.currentRun.CurrentSegment() == segment {
+			log.Printf("ignored split %s into segment %d, under the run's minimum segment time",
+				formatting.TimeFormatMilliseconds(t.currentRun.SegmentElapsed().Milliseconds()), segment)
+		}
+		if t.applyRules(t.currentRun.Firings()[fired:]) {
+			return
+		}
+	}
+
+	for _, c := range t.components {
+		c.Update()
 	}
+}
 
-	for idx, l := range t.labels.splits {
-		s := t.currentRun.GetSplit(idx)
-		l.Text = (&s).String()
-		l.Refresh()
+// applyRules acts on the rules that just fired, returning if one of them reset the timer.
+func (t *TimerLayout) applyRules(firings []timer.RuleFiring) bool {
+	reset := false
+	for _, f := range firings {
+		log.Printf("dead run rule %d fired at split %d (%s): %s", f.Rule, f.Split+1, t.run.Rules[f.Rule], f.Action)
+		reset = reset || f.Action == timer.RuleReset
 	}
+	if reset {
+		// golds made before the run died are still real, so they are kept
+		t.finishReset(t.currentRun.Restart)
+	}
+	return reset
+}
 
-	for idx, l := range t.labels.deltas {
-		s := t.currentRun.GetSplit(idx)
-		l.Text = (&s).Delta()
-		l.Refresh()
+// bouncing returns if a press of the key came too soon after the last one to be deliberate,
+// e.g. from a bouncing switch or a double press.
+func (t *TimerLayout) bouncing(key fyne.KeyName) bool {
+	now := time.Now()
+	since := now.Sub(t.lastPress[key])
+	if since < t.options.Debounce[key] {
+		log.Printf("ignored %s press %dms after the last one", key, since.Milliseconds())
+		return true
 	}
+
+	t.lastPress[key] = now
+	return false
 }
 
 func (t *TimerLayout) activateTimer() {
-	ticker := time.NewTicker(time.Second / 60)
-	// note: ticker will only stop on app close
-	go func(ticker *time.Ticker) {
-		for range ticker.C {
-			t.labels.clock.Text = t.currentRun.String()
-			t.labels.clock.Refresh()
+	var tickers []Ticker
+	for _, c := range t.components {
+		if ticker, ok := c.(Ticker); ok {
+			tickers = append(tickers, ticker)
 		}
-	}(ticker)
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(t.options.RefreshRate))
+	t.stop = make(chan struct{})
+	// note: ticker will only stop on app close or when the layout is replaced
+	go func(ticker *time.Ticker, stop chan struct{}) {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, c := range tickers {
+					c.Tick()
+				}
+			case <-stop:
+				return
+			}
+		}
+	}(ticker, t.stop)
 }
 
 func (t *TimerLayout) arrangeContent() fyne.CanvasObject {
-	var interleavedLabels []fyne.CanvasObject
-	for i := range t.labels.splits {
-		// assuming the 3 label arrays are of equal length
-		interleavedLabels = append(
-			interleavedLabels,
-			t.labels.splitNames[i],
-			t.labels.deltas[i],
-			t.labels.splits[i],
-		)
-	}
-
-	out := container.NewVBox(
-		t.labels.game,
-		t.labels.category,
-		container.NewGridWithColumns(3, interleavedLabels...),
-		layout.NewSpacer(),
-		t.labels.clock,
-	)
-	return out
+	var objects []fyne.CanvasObject
+	for _, c := range t.components {
+		objects = append(objects, c.Object())
+	}
+
+	return container.NewVBox(objects...)
 }
 
 func (t *TimerLayout) Show(window fyne.Window) fyne.CanvasObject {
+	t.window = window
 	window.Canvas().SetOnTypedKey(t.handleKeyInput)
 	t.activateTimer()
 	return t.arrangeContent()
 }
+
+// Restore carries on an attempt from a saved timer state.
+func (t *TimerLayout) Restore(s timer.State) error {
+	if err := t.currentRun.Restore(s); err != nil {
+		return err
+	}
+
+	for _, c := range t.components {
+		c.Update()
+	}
+	return nil
+}
+
+// InProgress returns if an attempt has been started and not yet reset.
+func (t *TimerLayout) InProgress() bool {
+	return !t.currentRun.Idle()
+}
+
+// Close stops redrawing the layout, so that it can be replaced by another.
+func (t *TimerLayout) Close() {
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+}
@@ -0,0 +1,27 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBouncing(t *testing.T) {
+	tl := &TimerLayout{
+		options:   Options{Debounce: map[fyne.KeyName]time.Duration{fyne.KeyReturn: time.Hour}},
+		lastPress: map[fyne.KeyName]time.Time{},
+	}
+
+	assert.False(t, tl.bouncing(fyne.KeyReturn), "the first press is acted on")
+	assert.True(t, tl.bouncing(fyne.KeyReturn), "a second press within the debounce time is ignored")
+	assert.False(t, tl.bouncing(fyne.KeySpace), "keys are debounced separately")
+	assert.False(t, tl.bouncing(fyne.KeySpace), "keys without a debounce time are never ignored")
+}
+
+func TestCountdownText(t *testing.T) {
+	assert.Equal(t, "3", countdownText(3*time.Second), "whole seconds are shown as they are")
+	assert.Equal(t, "3", countdownText(2001*time.Millisecond), "part seconds round up")
+	assert.Equal(t, "1", countdownText(time.Millisecond), "the last second shows 1 until the start")
+}
@@ -0,0 +1,99 @@
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"speedruntimer/timing/stats"
+	"speedruntimer/timing/timer"
+)
+
+type pbChanceSettings struct {
+	infoSettings
+	// Target is the time to beat. 0 uses the PB.
+	Target time.Duration
+	// Samples is how many attempts are simulated for each estimate.
+	Samples int
+}
+
+// pbChanceInterval is how often the estimate is redone while a segment is being run,
+// as simulating the attempt is too slow to do every frame.
+const pbChanceInterval = time.Second
+
+// pbChance estimates the chance of the attempt finishing under the PB, updated after every split
+// and every pbChanceInterval while running, as the current segment taking longer lowers the chance.
+type pbChance struct {
+	*infoRow
+	settings pbChanceSettings
+	time     timer.Timer
+	run      *timer.Run
+
+	// mu guards rng and updated, as Tick runs on the ticker's goroutine.
+	mu      sync.Mutex
+	rng     *rand.Rand
+	updated time.Time
+}
+
+func newPBChance(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := pbChanceSettings{infoSettings: infoSettings{Label: "PB Chance"}, Samples: 10000}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+	if settings.Samples <= 0 {
+		return nil, fmt.Errorf("PB chance samples must be positive, not %d", settings.Samples)
+	}
+
+	ret := &pbChance{infoRow: newInfoRow(settings.Label), settings: settings, time: t, run: run,
+		rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	ret.Update()
+	return ret, nil
+}
+
+func (p *pbChance) Update() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.update()
+}
+
+func (p *pbChance) Tick() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.time.Running() && time.Since(p.updated) >= pbChanceInterval {
+		p.update()
+	}
+}
+
+func (p *pbChance) update() {
+	p.updated = time.Now()
+
+	target := p.settings.Target
+	if target == 0 {
+		target = p.run.PBTime()
+	}
+	if target == 0 {
+		p.setValue("-")
+		return
+	}
+
+	from := p.time.CurrentSegment()
+	if p.time.Stopped() && from < len(p.run.Segments) {
+		// the attempt was ended early, so it can't finish
+		p.setValue("0%")
+		return
+	}
+
+	elapsed := time.Duration(0)
+	if p.time.Running() {
+		elapsed = p.time.SegmentElapsed()
+	}
+	chance, ok := stats.FinishChance(p.run, from, p.time.PreviousSplitTime(), elapsed, target, p.settings.Samples, p.rng)
+	if !ok {
+		p.setValue("-")
+		return
+	}
+	p.setValue(fmt.Sprintf("%.1f%%", chance*100))
+}
@@ -0,0 +1,54 @@
+package layout
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// reset resets the stopped timer. If the attempt has new golds or is a PB, it first asks
+// whether to keep the attempt or discard it, since they can't be taken back later.
+func (t *TimerLayout) reset() {
+	isPB, golds := t.currentRun.IsPB(), t.currentRun.HasNewGolds()
+	if !isPB && !golds {
+		t.finishReset(t.currentRun.Restart)
+		return
+	}
+
+	var d dialog.Dialog
+	keep := widget.NewButton("Keep Attempt", func() {
+		d.Hide()
+		t.finishReset(t.currentRun.Restart)
+	})
+	discard := widget.NewButton("Discard Attempt", func() {
+		d.Hide()
+		t.finishReset(t.currentRun.Discard)
+	})
+
+	message := "Discarding the attempt forgets it, as if it never happened."
+	if isPB {
+		message += "\nThis attempt is a PB, and discarding it throws the PB away too."
+	}
+	if golds {
+		message += "\nThis attempt has new best segments, and discarding it throws them away too."
+	}
+
+	d = dialog.NewCustom("Reset", "Cancel", container.NewVBox(
+		widget.NewLabel(message),
+		container.NewHBox(keep, discard),
+	), t.window)
+	d.Show()
+}
+
+func (t *TimerLayout) finishReset(reset func() time.Time) {
+	reset()
+	if t.options.OnReset != nil {
+		t.options.OnReset()
+	}
+
+	for _, c := range t.components {
+		c.Update()
+	}
+}
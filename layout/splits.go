@@ -0,0 +1,280 @@
+package layout
+
+import (
+	"encoding/json"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/stats"
+	"speedruntimer/timing/timer"
+)
+
+type splitsSettings struct {
+	ShowDeltas bool
+	// VisibleRows is how many rows are shown at once. 0 shows every row.
+	VisibleRows int
+	// Lookahead is how many upcoming rows are kept in view below the current one.
+	Lookahead int
+	// PinLastSplit keeps the final split in the bottom row regardless of scrolling.
+	PinLastSplit bool
+	// ShowAverage adds a column with each segment's average time over past attempts.
+	ShowAverage bool
+	// ShowGrades adds each completed segment's letter grade after its delta.
+	ShowGrades bool
+	// AdjustGrades grades each segment relative to how the attempt went before it.
+	AdjustGrades bool
+}
+
+type splitRow struct {
+	name    *widget.Label
+	delta   *widget.RichText
+	average *widget.Label
+	split   *widget.Label
+}
+
+func newSplitRow() *splitRow {
+	delta := widget.NewRichText(&widget.TextSegment{Style: widget.RichTextStyleInline})
+	return &splitRow{widget.NewLabel(""), delta, widget.NewLabel(""), widget.NewLabel("")}
+}
+
+func (r *splitRow) setDelta(text string, color fyne.ThemeColorName) {
+	segment := r.delta.Segments[0].(*widget.TextSegment)
+	segment.Text = text
+	segment.Style.ColorName = color
+	r.delta.Refresh()
+}
+
+// splitsItem is one row's worth of content: either a split, or the header of a section.
+type splitsItem struct {
+	name string
+	// split is the index of the split whose times are shown, or -1 for none
+	split  int
+	header bool
+	indent bool
+}
+
+type splits struct {
+	settings splitsSettings
+	time     timer.Timer
+	run      *timer.Run
+
+	rows    []*splitRow
+	content *fyne.Container
+}
+
+func newSplits(t timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := splitsSettings{ShowDeltas: true, Lookahead: 1, PinLastSplit: true}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	columns := 2
+	if settings.ShowDeltas {
+		columns++
+	}
+	if settings.ShowAverage {
+		columns++
+	}
+
+	ret := &splits{settings: settings, time: t, run: run, content: container.NewGridWithColumns(columns)}
+	ret.Update()
+	return ret, nil
+}
+
+func (s *splits) Object() fyne.CanvasObject {
+	return s.content
+}
+
+// items lists what should be shown for the run, with every section but the current one
+// collapsed to its header. It also returns which item the current split is in.
+func (s *splits) items() (out []splitsItem, current int) {
+	// Special case: no run loaded
+	if len(s.run.Segments) == 1 && s.run.Segments[0].Name == "" {
+		return nil, 0
+	}
+
+	currentSplit := s.time.CurrentSegment()
+	if currentSplit >= len(s.run.Segments) {
+		currentSplit = len(s.run.Segments) - 1
+	}
+
+	var expanded timer.SectionRange
+	sections := s.run.SectionRanges()
+	for idx := 0; idx < len(s.run.Segments); idx++ {
+		if len(sections) > 0 && sections[0].Start == idx {
+			section := sections[0]
+			sections = sections[1:]
+
+			if !section.Contains(currentSplit) {
+				// the header stands in for the whole section, showing its total
+				out = append(out, splitsItem{section.Name, section.End - 1, true, false})
+				idx = section.End - 1
+				continue
+			}
+
+			expanded = section
+			out = append(out, splitsItem{section.Name, -1, true, false})
+		}
+
+		if idx == currentSplit {
+			current = len(out)
+		}
+		out = append(out, splitsItem{s.run.Segments[idx].Name, idx, false, expanded.Contains(idx)})
+	}
+
+	return out, current
+}
+
+func (s *splits) Update() {
+	items, current := s.items()
+
+	rowCount := len(items)
+	if s.settings.VisibleRows > 0 && s.settings.VisibleRows < rowCount {
+		rowCount = s.settings.VisibleRows
+	}
+	for len(s.rows) < rowCount {
+		s.rows = append(s.rows, newSplitRow())
+	}
+
+	var averages []stats.Summary
+	if s.settings.ShowAverage {
+		averages = stats.Segments(s.run)
+	}
+
+	var grades []stats.Grade
+	if s.settings.ShowGrades {
+		grades = stats.GradeSegments(s.run, stats.AttemptSegments(s.time, s.run), s.settings.AdjustGrades)
+	}
+
+	shown := visibleSplits(len(items), current, rowCount, s.settings.Lookahead, s.settings.PinLastSplit)
+
+	var interleavedLabels []fyne.CanvasObject
+	for i, idx := range shown {
+		r := s.rows[i]
+		item := items[idx]
+
+		r.name.TextStyle.Bold = item.header || (idx == current && s.time.CurrentSegment() < len(s.run.Segments))
+		if item.indent {
+			r.name.SetText("  " + item.name)
+		} else {
+			r.name.SetText(item.name)
+		}
+
+		r.average.SetText("")
+		if s.settings.ShowAverage && !item.header && averages[item.split].Count > 0 {
+			r.average.SetText(formatting.TimeFormatMilliseconds(averages[item.split].Mean.Milliseconds()))
+		}
+
+		if item.split < 0 {
+			r.setDelta("", theme.ColorNameForeground)
+			r.split.SetText("")
+		} else if item.header {
+			total, delta, finished, gold := s.sectionTotals(s.sectionEndingAt(item.split))
+			if finished && s.run.Segments[item.split].PBTime != 0 {
+				r.setDelta(formatting.DeltaFormatMilliseconds(delta.Milliseconds()), deltaColor(delta, gold))
+			} else {
+				r.setDelta("", theme.ColorNameForeground)
+			}
+			r.split.SetText(formatting.TimeFormatMilliseconds(total.Milliseconds()))
+		} else {
+			split := s.time.GetSplit(item.split)
+			delta := split.Delta()
+			if item.split < len(grades) && grades[item.split] != "" {
+				delta += " " + string(grades[item.split])
+			}
+			r.setDelta(delta, deltaColor(split.ActiveRunTime-split.PBTime, split.IsGold()))
+			r.split.SetText(split.String())
+		}
+
+		interleavedLabels = append(interleavedLabels, r.name)
+		if s.settings.ShowDeltas {
+			interleavedLabels = append(interleavedLabels, r.delta)
+		}
+		if s.settings.ShowAverage {
+			interleavedLabels = append(interleavedLabels, r.average)
+		}
+		interleavedLabels = append(interleavedLabels, r.split)
+	}
+
+	s.content.Objects = interleavedLabels
+	s.content.Refresh()
+}
+
+// sectionEndingAt returns the section whose last segment is at idx.
+func (s *splits) sectionEndingAt(idx int) timer.SectionRange {
+	for _, section := range s.run.SectionRanges() {
+		if section.End-1 == idx {
+			return section
+		}
+	}
+	return timer.SectionRange{Start: idx, End: idx + 1}
+}
+
+// sectionTotals adds up the section's segments: the attempt's times once the section is finished,
+// or the PB's before then. delta compares a finished section with the PB, and gold is whether it has any golds.
+func (s *splits) sectionTotals(section timer.SectionRange) (total, delta time.Duration, finished, gold bool) {
+	finished = s.time.CurrentSegment() >= section.End
+
+	var pb time.Duration
+	for idx := section.Start; idx < section.End; idx++ {
+		pb += s.run.PBSegment(idx)
+		if finished {
+			split := s.time.GetSplit(idx)
+			total += split.ActiveSegment
+			gold = gold || split.IsGold()
+		}
+	}
+
+	if !finished {
+		return pb, 0, false, false
+	}
+	return total, total - pb, true, gold
+}
+
+// visibleSplits returns the indices of the items to show in each of the given number of rows,
+// scrolled so that the current item and the lookahead after it are in view.
+func visibleSplits(total, current, rows, lookahead int, pinLast bool) []int {
+	if rows > total {
+		rows = total
+	}
+
+	scrolling := total
+	window := rows
+	// with a single row, pinning would leave no room for the current item
+	if pinLast && rows < total && rows >= 2 {
+		// the last row always belongs to the final item, so the rest scroll over the others
+		scrolling--
+		window--
+	}
+
+	// the current item itself must stay in view too
+	if lookahead > window-1 {
+		lookahead = window - 1
+	}
+	if lookahead < 0 {
+		lookahead = 0
+	}
+
+	end := current + lookahead + 1
+	if end > scrolling {
+		end = scrolling
+	}
+	start := end - window
+	if start < 0 {
+		start = 0
+	}
+
+	out := make([]int, 0, rows)
+	for i := start; i < start+window; i++ {
+		out = append(out, i)
+	}
+	if len(out) < rows {
+		out = append(out, total-1)
+	}
+	return out
+}
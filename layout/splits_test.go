@@ -0,0 +1,91 @@
+package layout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestVisibleSplits(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, visibleSplits(3, 0, 3, 1, true),
+		"every split is shown when there are enough rows")
+
+	assert.Equal(t, []int{0, 1, 2, 3, 99}, visibleSplits(100, 0, 5, 1, true),
+		"at the start, rows are filled from the first split")
+	assert.Equal(t, []int{47, 48, 49, 50, 99}, visibleSplits(100, 49, 5, 1, true),
+		"the current split is kept in view with lookahead after it")
+	assert.Equal(t, []int{95, 96, 97, 98, 99}, visibleSplits(100, 98, 5, 1, true),
+		"scrolling stops before the pinned final split")
+	assert.Equal(t, []int{95, 96, 97, 98, 99}, visibleSplits(100, 100, 5, 1, true),
+		"a finished run stays scrolled to the end")
+
+	assert.Equal(t, []int{46, 47, 48, 49, 50}, visibleSplits(100, 49, 5, 1, false),
+		"without pinning, every row scrolls")
+	assert.Equal(t, []int{49, 50, 51, 52, 53}, visibleSplits(100, 49, 5, 10, false),
+		"lookahead never scrolls the current split out of view")
+	assert.Equal(t, []int{49}, visibleSplits(100, 49, 1, 1, true),
+		"a single row shows the current split, not the pinned final one")
+}
+
+func TestSplitsItems(t *testing.T) {
+	run := &timer.Run{
+		Segments: []*timer.Split{{Name: "1-1"}, {Name: "1-2"}, {Name: "2-1"}, {Name: "2-2"}, {Name: "Final Boss"}},
+		Sections: []timer.Section{{Name: "World 1", Size: 2}, {Name: "World 2", Size: 2}},
+	}
+	time, _ := timer.New(run)
+	s := &splits{time: time, run: run}
+
+	items, current := s.items()
+	assert.Equal(t, []splitsItem{
+		{"World 1", -1, true, false},
+		{"1-1", 0, false, true},
+		{"1-2", 1, false, true},
+		{"World 2", 3, true, false},
+		{"Final Boss", 4, false, false},
+	}, items, "only the current section is expanded; the others show their last split")
+	assert.Equal(t, 1, current, "the current item is the current split")
+
+	time.Split() // starts the timer
+	time.Split()
+	time.Split()
+	items, current = s.items()
+	assert.Equal(t, []splitsItem{
+		{"World 1", 1, true, false},
+		{"World 2", -1, true, false},
+		{"2-1", 2, false, true},
+		{"2-2", 3, false, true},
+		{"Final Boss", 4, false, false},
+	}, items, "finished sections collapse to their header")
+	assert.Equal(t, 2, current, "the current item follows the current split into the next section")
+}
+
+func TestSectionTotals(t *testing.T) {
+	run := &timer.Run{
+		Segments: []*timer.Split{
+			{Name: "1-1", PBTime: 10 * time.Second, BestSegment: 10 * time.Second},
+			{Name: "1-2", PBTime: 25 * time.Second, BestSegment: 15 * time.Second},
+			{Name: "Final Boss", PBTime: 45 * time.Second, BestSegment: 20 * time.Second},
+		},
+		Sections: []timer.Section{{Name: "World 1", Size: 2}},
+	}
+	tm, _ := timer.New(run)
+	s := &splits{time: tm, run: run}
+	world := s.sectionEndingAt(1)
+	assert.Equal(t, timer.SectionRange{Name: "World 1", Start: 0, End: 2}, world)
+
+	total, _, finished, _ := s.sectionTotals(world)
+	assert.False(t, finished)
+	assert.Equal(t, 25*time.Second, total, "an unfinished section shows the PB's total for it")
+
+	tm.Split() // starts the timer
+	tm.Split()
+	tm.Split()
+	total, delta, finished, gold := s.sectionTotals(world)
+	assert.True(t, finished)
+	assert.Less(t, total, time.Second, "a finished section shows the attempt's total for it")
+	assert.Equal(t, total-25*time.Second, delta, "the delta is for the section alone, not the run so far")
+	assert.True(t, gold)
+}
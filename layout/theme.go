@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"speedruntimer/style"
+)
+
+func currentTheme() fyne.Theme {
+	if app := fyne.CurrentApp(); app != nil {
+		return app.Settings().Theme()
+	}
+	return style.Default()
+}
+
+// themeColor looks up a color in the current theme, for objects that
+// don't do so themselves like canvas.Text.
+func themeColor(name fyne.ThemeColorName) color.Color {
+	return currentTheme().Color(name, theme.VariantDark)
+}
+
+func themeSize(name fyne.ThemeSizeName) float32 {
+	return currentTheme().Size(name)
+}
+
+// deltaColor returns which color a time should be shown in,
+// given how it compares to the time it is measured against.
+func deltaColor(delta time.Duration, gold bool) fyne.ThemeColorName {
+	if gold {
+		return style.ColorNameGold
+	}
+	if delta > 0 {
+		return style.ColorNameBehind
+	}
+	if delta < 0 {
+		return style.ColorNameAhead
+	}
+	return theme.ColorNameForeground
+}
@@ -0,0 +1,54 @@
+package layout
+
+import (
+	"encoding/json"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+
+	"speedruntimer/style"
+	"speedruntimer/timing/timer"
+)
+
+type titleSettings struct {
+	ShowGameName bool
+	ShowCategory bool
+}
+
+type title struct {
+	content *fyne.Container
+}
+
+func newTitle(_ timer.Timer, run *timer.Run, raw json.RawMessage) (Component, error) {
+	settings := titleSettings{ShowGameName: true, ShowCategory: true}
+	if err := decodeSettings(raw, &settings); err != nil {
+		return nil, err
+	}
+
+	ret := &title{container.NewVBox()}
+
+	if settings.ShowGameName {
+		game := canvas.NewText(run.GameName, themeColor(theme.ColorNameForeground))
+		game.TextSize = themeSize(style.SizeNameTitle)
+		game.Alignment = fyne.TextAlignCenter
+		ret.content.Add(game)
+	}
+
+	if settings.ShowCategory {
+		category := canvas.NewText(run.Category, themeColor(theme.ColorNameForeground))
+		category.TextSize = themeSize(style.SizeNameCategory)
+		category.Alignment = fyne.TextAlignCenter
+		ret.content.Add(category)
+	}
+
+	return ret, nil
+}
+
+func (t *title) Object() fyne.CanvasObject {
+	return t.content
+}
+
+// The title only changes when a different run is loaded, which builds a new layout.
+func (t *title) Update() {}
@@ -1,20 +1,20 @@
 package main
 
 import (
-	"encoding/json"
 	"speedruntimer/config"
+	"speedruntimer/journal"
 	"speedruntimer/layout"
+	"speedruntimer/splitfile"
+	"speedruntimer/style"
 	"speedruntimer/timing/timer"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/theme"
 
 	"os"
+	"time"
 
-	"github.com/adrg/xdg"
-	"github.com/jinzhu/configor"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -22,85 +22,260 @@ import (
 func init() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+}
 
-	if _, themeSet := os.LookupEnv("FYNE_THEME"); !themeSet {
-		os.Setenv("FYNE_THEME", "dark")
-	}
+type timerApp struct {
+	app          fyne.App
+	window       fyne.Window
+	dialogwindow fyne.Window
+
+	conf        *config.Config
+	run         *timer.Run
+	layoutFile  *layout.File
+	timerLayout *layout.TimerLayout
+	journal     *journal.Journal
 }
 
 func main() {
-	var (
-		app    = app.New()
-		window = app.NewWindow("Timer")
-		run    = timer.DefaultRun()
-	)
+	a := &timerApp{
+		app:        app.New(),
+		run:        timer.DefaultRun(),
+		layoutFile: layout.DefaultFile(),
+	}
+	a.window = a.app.NewWindow("Timer")
 
-	app.Settings().SetTheme(theme.DefaultTheme())
+	a.app.Settings().SetTheme(style.Default())
 
-	// Fixed size mode enforces a floating window by default, which we want,
-	// but we want that size to be saved with the run data and not hardcoded
-	window.SetFixedSize(true)
-	window.Resize(fyne.NewSize(540, 300))
-	window.SetMaster()
+	// Fixed size mode enforces a floating window by default, which we want.
+	// The size itself is saved per split file; see window.go
+	a.window.SetFixedSize(true)
+	a.window.Resize(fyne.NewSize(540, 300))
+	a.window.SetMaster()
+	a.window.SetCloseIntercept(func() {
+		a.saveWindowSize()
+		a.saveRun()
+		if a.journal != nil {
+			a.journal.Close()
+		}
+		a.window.Close()
+	})
 
-	dialogwindow := app.NewWindow("Dialog")
-	dialogwindow.Resize(fyne.NewSize(540, 300))
+	a.dialogwindow = a.app.NewWindow("Dialog")
+	a.dialogwindow.Resize(fyne.NewSize(540, 300))
 
-	conf, cfgerr := config.OpenConfigFile()
+	// Open always returns a usable config, even if there was a problem with the file
+	conf, cfgerr := config.Open()
 	if cfgerr != nil {
-		dialogwindow.Show()
-		dialog.NewError(cfgerr, dialogwindow)
-		// TODO: pause main execution until closed?
+		a.showError(cfgerr)
+	}
+	a.conf = conf
+	a.conf.PruneRecentSplitFiles()
+	if conf.LastSplitFile != "" {
+		a.conf.AddRecentSplitFile(conf.LastSplitFile)
 	}
+	a.window.SetMainMenu(a.mainMenu())
+	a.window.Canvas().AddShortcut(switchRunShortcut, func(fyne.Shortcut) { a.showSwitchRun() })
 
-	var loadSplitFile = func(f fyne.URIReadCloser, e error) {
-		if e != nil {
-			dialogwindow.Show()
-			dialog.NewError(e, dialogwindow)
-			// TODO: pause main execution until closed?
+	if conf.ThemeFile != "" {
+		t, err := style.LoadFile(conf.ThemeFile)
+		if err != nil {
+			log.Print("theme load error")
+			log.Print(err.Error())
+		} else {
+			a.app.Settings().SetTheme(t)
 		}
+	}
 
-		if f == nil {
-			tl := layout.NewTimerLayout(run).Show(window)
-			window.SetContent(tl)
-			return
+	if conf.LayoutFile != "" {
+		f, err := layout.LoadFile(conf.LayoutFile)
+		if err != nil {
+			log.Print("layout load error")
+			log.Print(err.Error())
+		} else {
+			a.layoutFile = f
 		}
+	}
 
-		conf.LastSplitFile = f.URI().Path()
+	// TODO: move this out of main
+	if conf.LastSplitFile == "" {
+		a.rebuildLayout()
+		a.dialogwindow.Show()
+		dialog.NewFileOpen(a.loadSplitFile, a.dialogwindow).Show()
+		a.restoreWindowSize()
+	} else {
+		a.loadRun(conf.LastSplitFile)
+		a.rebuildLayout()
+		a.restoreWindowSize()
+		a.offerResume()
+	}
 
-		s, _ := xdg.ConfigFile("speedruntimer/config") // Unhandled potential error
-		newfile, _ := os.Create(s)                     // Unhandled potential error
-		confbytes, _ := json.Marshal(conf)             // Unhandled potential error
-		newfile.Write(confbytes)
+	a.window.ShowAndRun()
+}
 
-		e = configor.Load(run, conf.LastSplitFile)
-		if e != nil {
-			log.Print("split load error")
-			log.Print(e.Error()) // TODO: filter out the usual error
-		}
+func (a *timerApp) showError(err error) {
+	a.dialogwindow.Show()
+	dialog.ShowError(err, a.dialogwindow)
+}
 
-		tl := layout.NewTimerLayout(run).Show(window)
-		window.SetContent(tl)
-		window.Resize(fyne.NewSize(window.Content().MinSize().Width, 720))
+// rebuildLayout replaces the window content with a fresh TimerLayout
+// for the current run and layout file.
+func (a *timerApp) rebuildLayout() {
+	// the old journal finishes writing before the new one starts, so they can't overwrite each other
+	if a.journal != nil {
+		a.journal.Close()
+	}
+	a.journal = journal.New(a.conf.LastSplitFile, func(err error) {
+		log.Print("journal write error")
+		log.Print(err.Error())
+	})
 
-		dialogwindow.Hide()
+	options := layout.Options{
+		SplitKey:     a.conf.Hotkeys.Split,
+		PauseKey:     a.conf.Hotkeys.Pause,
+		ResetKey:     a.conf.Hotkeys.Reset,
+		UndoResetKey: a.conf.Hotkeys.UndoReset,
+		Debounce: map[fyne.KeyName]time.Duration{
+			a.conf.Hotkeys.Split:     a.conf.Debounce.Split,
+			a.conf.Hotkeys.Pause:     a.conf.Debounce.Pause,
+			a.conf.Hotkeys.Reset:     a.conf.Debounce.Reset,
+			a.conf.Hotkeys.UndoReset: a.conf.Debounce.UndoReset,
+		},
+		RefreshRate: a.conf.RefreshRate,
+		// resetting is when PBs are recorded, so they are saved straight away
+		OnReset: a.saveRun,
+		Journal: a.journal,
 	}
 
-	// TODO: move this out of main
-	if conf.LastSplitFile == "" {
-		dialogwindow.Show()
-		dialog.NewFileOpen(loadSplitFile, dialogwindow).Show()
-		window.Resize(fyne.NewSize(320, 720))
-	} else {
-		err := configor.Load(run, conf.LastSplitFile)
-		if err != nil {
-			log.Print("split load error")
-			log.Print(err.Error()) // TODO: filter out the usual error
-		}
-		window.Resize(fyne.NewSize(window.Content().MinSize().Width, 720))
+	tl, err := layout.NewTimerLayout(a.run, a.layoutFile, options)
+	if err != nil {
+		a.showError(err)
+		tl, _ = layout.NewTimerLayout(a.run, layout.DefaultFile(), options)
+	}
+
+	if a.timerLayout != nil {
+		a.timerLayout.Close()
+	}
+	a.timerLayout = tl
+	a.window.SetContent(tl.Show(a.window))
+}
+
+func (a *timerApp) saveConfig() {
+	if err := a.conf.Save(); err != nil {
+		a.showError(err)
+	}
+}
+
+func (a *timerApp) loadSplitFile(f fyne.URIReadCloser, e error) {
+	if e != nil {
+		a.showError(e)
+		// TODO: pause main execution until closed?
 	}
 
-	tl := layout.NewTimerLayout(run).Show(window)
-	window.SetContent(tl)
-	window.ShowAndRun()
+	if f == nil {
+		a.rebuildLayout()
+		return
+	}
+	f.Close()
+
+	a.dialogwindow.Hide()
+	a.switchRun(f.URI().Path())
+}
+
+// switchRun replaces the loaded run with the one from the split file at path,
+// first asking whether to throw away the attempt in progress, if there is one.
+func (a *timerApp) switchRun(path string) {
+	if a.timerLayout == nil || !a.timerLayout.InProgress() {
+		a.loadAndSwitchRun(path)
+		return
+	}
+
+	a.dialogwindow.Show()
+	dialog.ShowConfirm("Switch Run", "An attempt is in progress, and switching runs will throw it away.\nSwitch anyway?",
+		func(ok bool) {
+			a.dialogwindow.Hide()
+			if ok {
+				a.loadAndSwitchRun(path)
+			}
+		}, a.dialogwindow)
+}
+
+// loadAndSwitchRun loads the split file at path, and only if it can be read makes it the loaded run.
+func (a *timerApp) loadAndSwitchRun(path string) {
+	run, err := splitfile.Load(path)
+	if err != nil {
+		// the file may have gone since it was last opened
+		a.conf.PruneRecentSplitFiles()
+		a.saveConfig()
+		a.window.SetMainMenu(a.mainMenu())
+		a.showError(err)
+		return
+	}
+
+	a.saveWindowSize()
+	a.saveRun()
+	a.run = run
+	a.conf.LastSplitFile = path
+	a.conf.AddRecentSplitFile(path)
+	a.saveConfig()
+
+	a.rebuildLayout()
+	a.restoreWindowSize()
+
+	// the recent split files have changed
+	a.window.SetMainMenu(a.mainMenu())
+}
+
+func (a *timerApp) loadLayoutFile(f fyne.URIReadCloser, e error) {
+	if e != nil {
+		a.showError(e)
+		return
+	}
+
+	if f == nil {
+		a.dialogwindow.Hide()
+		return
+	}
+	f.Close()
+
+	lf, err := layout.LoadFile(f.URI().Path())
+	if err != nil {
+		a.showError(err)
+		return
+	}
+
+	a.conf.LayoutFile = f.URI().Path()
+	a.saveConfig()
+
+	a.layoutFile = lf
+	a.rebuildLayout()
+
+	a.dialogwindow.Hide()
+}
+
+func (a *timerApp) loadThemeFile(f fyne.URIReadCloser, e error) {
+	if e != nil {
+		a.showError(e)
+		return
+	}
+
+	if f == nil {
+		a.dialogwindow.Hide()
+		return
+	}
+	f.Close()
+
+	t, err := style.LoadFile(f.URI().Path())
+	if err != nil {
+		a.showError(err)
+		return
+	}
+
+	a.conf.ThemeFile = f.URI().Path()
+	a.saveConfig()
+
+	a.app.Settings().SetTheme(t)
+	// some components take their colors and sizes from the theme when built
+	a.rebuildLayout()
+
+	a.dialogwindow.Hide()
 }
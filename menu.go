@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"speedruntimer/layout"
+	"speedruntimer/style"
+)
+
+func (a *timerApp) mainMenu() *fyne.MainMenu {
+	openSplits := fyne.NewMenuItem("Open Splits...", func() {
+		a.dialogwindow.Show()
+		dialog.ShowFileOpen(a.loadSplitFile, a.dialogwindow)
+	})
+
+	switchRun := fyne.NewMenuItem("Switch Run", nil)
+	switchRun.ChildMenu = a.recentRunsMenu()
+
+	openLayout := fyne.NewMenuItem("Open Layout...", func() {
+		a.dialogwindow.Show()
+		dialog.ShowFileOpen(a.loadLayoutFile, a.dialogwindow)
+	})
+
+	defaultLayout := fyne.NewMenuItem("Default Layout", func() {
+		a.conf.LayoutFile = ""
+		a.saveConfig()
+
+		a.layoutFile = layout.DefaultFile()
+		a.rebuildLayout()
+	})
+
+	openTheme := fyne.NewMenuItem("Open Theme...", func() {
+		a.dialogwindow.Show()
+		dialog.ShowFileOpen(a.loadThemeFile, a.dialogwindow)
+	})
+
+	defaultTheme := fyne.NewMenuItem("Default Theme", func() {
+		a.conf.ThemeFile = ""
+		a.saveConfig()
+
+		a.app.Settings().SetTheme(style.Default())
+		a.rebuildLayout()
+	})
+
+	resizing := fyne.NewMenuItem("Unlock Window Size", nil)
+	resizing.Action = func() { a.toggleWindowResizing(resizing) }
+
+	fitContent := fyne.NewMenuItem("Fit Window to Content", func() {
+		a.window.Resize(a.window.Content().MinSize())
+		a.saveWindowSize()
+	})
+
+	windowMenu := fyne.NewMenu("Window", resizing, fitContent)
+
+	runMenu := fyne.NewMenu("Run",
+		fyne.NewMenuItem("History...", a.showHistory),
+		fyne.NewMenuItem("Statistics...", a.showStats),
+		fyne.NewMenuItem("PB History...", a.showPBHistory),
+		fyne.NewMenuItem("Sum of Best Cleaner...", a.showSumOfBestCleaner),
+	)
+
+	return fyne.NewMainMenu(fyne.NewMenu("File",
+		openSplits, switchRun,
+		fyne.NewMenuItemSeparator(),
+		openLayout, defaultLayout,
+		fyne.NewMenuItemSeparator(),
+		openTheme, defaultTheme,
+	), runMenu, windowMenu)
+}
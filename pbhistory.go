@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/timer"
+)
+
+// showPBHistory opens a window listing every PB of the loaded run, newest first.
+// Each can be compared against the current PB, or restored in its place.
+func (a *timerApp) showPBHistory() {
+	w := a.app.NewWindow("PB History")
+	history := a.run.PBHistory
+
+	// newest first
+	pb := func(id widget.ListItemID) timer.PBRecord {
+		return history[len(history)-1-id]
+	}
+
+	detail := container.NewMax(widget.NewLabel("Select a PB to compare it with the current one."))
+	list := widget.NewList(
+		func() int { return len(history) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(pbLabel(pb(id)))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		idx := len(history) - 1 - id
+		restore := widget.NewButton("Restore This PB", func() {
+			a.confirmRestorePB(w, idx)
+		})
+		restore.Disable()
+		if idx < len(history)-1 {
+			restore.Enable()
+		}
+
+		detail.Objects = []fyne.CanvasObject{
+			container.NewBorder(nil, restore, nil, nil, a.comparePB(pb(id))),
+		}
+		detail.Refresh()
+	}
+
+	var content fyne.CanvasObject = container.NewHSplit(list, detail)
+	if len(history) == 0 {
+		content = widget.NewLabel("No PBs have been recorded for this run yet.")
+	}
+	w.SetContent(content)
+	w.Resize(fyne.NewSize(720, 400))
+	w.Show()
+}
+
+func pbLabel(p timer.PBRecord) string {
+	date := "Before history was kept"
+	if !p.Date.IsZero() {
+		date = p.Date.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprintf("%s  %s", formatting.TimeFormatMilliseconds(p.FinalTime().Milliseconds()), date)
+}
+
+// comparePB is a table of an older PB's splits against the current PB's.
+func (a *timerApp) comparePB(p timer.PBRecord) fyne.CanvasObject {
+	var rows [][]string
+	for i, s := range a.run.Segments {
+		if i >= len(p.Splits) {
+			break
+		}
+		rows = append(rows, []string{
+			s.Name,
+			formatting.TimeFormatMilliseconds(p.Splits[i].Milliseconds()),
+			formatting.TimeFormatMilliseconds(s.PBTime.Milliseconds()),
+			formatting.DeltaFormatMilliseconds((s.PBTime - p.Splits[i]).Milliseconds()),
+		})
+	}
+	return newStatsTable([]string{"Split", "This PB", "Current PB", "Difference"}, rows)
+}
+
+func (a *timerApp) confirmRestorePB(w fyne.Window, idx int) {
+	message := "Make this the PB again? Every later PB will be forgotten."
+	dialog.ShowConfirm("Restore PB", message, func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := a.run.RestorePB(idx); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+
+		a.saveRun()
+		a.rebuildLayout()
+		w.Close()
+	}, w)
+}
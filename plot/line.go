@@ -0,0 +1,48 @@
+// Package plot draws charts out of canvas objects.
+package plot
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+const lineWidth = 2
+
+// Line joins a series of points with straight segments, each of which can have its own color.
+type Line struct {
+	segments []*canvas.Line
+}
+
+// NewLine makes a line with a segment in each of the given colors, so it joins one more point than there are colors.
+func NewLine(colors ...color.Color) *Line {
+	l := &Line{}
+	for _, c := range colors {
+		segment := canvas.NewLine(c)
+		segment.StrokeWidth = lineWidth
+		l.segments = append(l.segments, segment)
+	}
+	return l
+}
+
+// Objects returns the segments, for the renderer drawing the line.
+func (l *Line) Objects() []fyne.CanvasObject {
+	out := make([]fyne.CanvasObject, len(l.segments))
+	for i, s := range l.segments {
+		out[i] = s
+	}
+	return out
+}
+
+// Place moves the segments to join up the points, which should be one more than the segments.
+// Segments without a point at each end are left where they are.
+func (l *Line) Place(points []fyne.Position) {
+	for i, s := range l.segments {
+		if i+1 >= len(points) {
+			return
+		}
+		s.Position1 = points[i]
+		s.Position2 = points[i+1]
+	}
+}
@@ -0,0 +1,23 @@
+package plot
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine(t *testing.T) {
+	l := NewLine(color.White, color.Black)
+	assert.Len(t, l.Objects(), 2, "there is a segment for every color")
+
+	l.Place([]fyne.Position{fyne.NewPos(0, 0), fyne.NewPos(10, 5), fyne.NewPos(20, 0)})
+	second := l.Objects()[1].(*canvas.Line)
+	assert.Equal(t, fyne.NewPos(10, 5), second.Position1, "the segments join up")
+	assert.Equal(t, fyne.NewPos(20, 0), second.Position2)
+	assert.Equal(t, color.Black, second.StrokeColor)
+
+	assert.NotPanics(t, func() { l.Place([]fyne.Position{fyne.NewPos(0, 0)}) }, "too few points leave the segments alone")
+}
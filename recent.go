@@ -0,0 +1,54 @@
+package main
+
+import (
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+var switchRunShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyR, Modifier: fyne.KeyModifierControl}
+
+// recentRunsMenu lists the recent split files, other than the one already loaded.
+func (a *timerApp) recentRunsMenu() *fyne.Menu {
+	var items []*fyne.MenuItem
+	for _, path := range a.conf.RecentSplitFiles {
+		if path == a.conf.LastSplitFile {
+			continue
+		}
+
+		path := path
+		items = append(items, fyne.NewMenuItem(filepath.Base(path), func() { a.switchRun(path) }))
+	}
+
+	if len(items) == 0 {
+		none := fyne.NewMenuItem("No Recent Runs", nil)
+		none.Disabled = true
+		items = append(items, none)
+	}
+	return fyne.NewMenu("", items...)
+}
+
+// showSwitchRun asks which of the recent split files to switch to.
+func (a *timerApp) showSwitchRun() {
+	recent := a.conf.RecentSplitFiles
+
+	var d dialog.Dialog
+	list := widget.NewList(
+		func() int { return len(recent) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, o fyne.CanvasObject) { o.(*widget.Label).SetText(recent[id]) },
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		d.Hide()
+		a.dialogwindow.Hide()
+		a.switchRun(recent[id])
+	}
+
+	a.dialogwindow.Show()
+	d = dialog.NewCustom("Switch Run", "Cancel", list, a.dialogwindow)
+	d.SetOnClosed(a.dialogwindow.Hide)
+	d.Show()
+}
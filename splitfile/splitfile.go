@@ -0,0 +1,144 @@
+// Package splitfile reads and writes split files, keeping backups of what they held before.
+package splitfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"speedruntimer/atomicfile"
+	"speedruntimer/timing/timer"
+)
+
+func Load(path string) (*timer.Run, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &timer.Run{}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, err
+	}
+	if err := run.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid split file: %w", err)
+	}
+	return run, nil
+}
+
+// Save writes run to path atomically. If there is already a split file at path,
+// it is backed up first, keeping at most keep backups for that file.
+func Save(run *timer.Run, path string, keep int) error {
+	data, err := json.MarshalIndent(run, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if keep > 0 {
+		if err := backup(path, keep); err != nil {
+			return fmt.Errorf("could not back up split file: %w", err)
+		}
+	}
+
+	return atomicfile.Write(path, data, 0644)
+}
+
+// Backup is an earlier version of a split file.
+type Backup struct {
+	Path string
+	Time time.Time
+}
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// backupDir returns where backups of the split file at path are kept.
+// Backups live in the XDG data dir, in a directory named after the split file
+// along with a hash of its full path, so that files with the same name don't mix.
+func backupDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New32a()
+	h.Write([]byte(abs))
+
+	name := fmt.Sprintf("%s-%08x", strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs)), h.Sum32())
+	placeholder, err := xdg.DataFile(filepath.Join("speedruntimer", "backups", name, "backup"))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(placeholder), nil
+}
+
+// Backups lists the backups of the split file at path, newest first.
+func Backups(path string) ([]Backup, error) {
+	dir, err := backupDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Backup
+	for _, e := range entries {
+		t, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(e.Name(), ".json"), time.Local)
+		if err != nil {
+			continue // not one of ours
+		}
+		out = append(out, Backup{filepath.Join(dir, e.Name()), t})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.After(out[j].Time) })
+	return out, nil
+}
+
+func backup(path string, keep int) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil // nothing to back up yet
+	}
+	if err != nil {
+		return err
+	}
+
+	dir, err := backupDir(path)
+	if err != nil {
+		return err
+	}
+	name := time.Now().Format(backupTimeFormat) + ".json"
+	if err := atomicfile.Write(filepath.Join(dir, name), data, 0644); err != nil {
+		return err
+	}
+
+	backups, err := Backups(path)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore replaces the split file at path with the backup.
+// The file being replaced is not backed up, as it is usually the reason for restoring.
+func Restore(b Backup, path string) error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return err
+	}
+	return atomicfile.Write(path, data, 0644)
+}
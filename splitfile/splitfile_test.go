@@ -0,0 +1,64 @@
+package splitfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func useTempDataDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func testRun(pb time.Duration) *timer.Run {
+	return &timer.Run{GameName: "Fake Game Title", Segments: []*timer.Split{{Name: "Fake Split 1", PBTime: pb}}}
+}
+
+func TestSaveLoad(t *testing.T) {
+	useTempDataDir(t)
+	path := filepath.Join(t.TempDir(), "run.json")
+
+	assert.Nil(t, Save(testRun(time.Minute), path, 3))
+	run, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, testRun(time.Minute), run, "a saved run should load unchanged")
+
+	assert.Nil(t, os.WriteFile(path, []byte(`{"Segments":[]}`), 0644))
+	_, err = Load(path)
+	assert.NotNil(t, err, "a split file without segments is an error")
+}
+
+func TestBackups(t *testing.T) {
+	useTempDataDir(t)
+	path := filepath.Join(t.TempDir(), "run.json")
+
+	assert.Nil(t, Save(testRun(1*time.Minute), path, 2))
+	backups, err := Backups(path)
+	assert.Nil(t, err)
+	assert.Empty(t, backups, "the first save has nothing to back up")
+
+	for i := 2; i <= 4; i++ {
+		time.Sleep(2 * time.Millisecond) // backups are named by time
+		assert.Nil(t, Save(testRun(time.Duration(i)*time.Minute), path, 2))
+	}
+	backups, err = Backups(path)
+	assert.Nil(t, err)
+	assert.Len(t, backups, 2, "only the newest backups are kept")
+
+	assert.Nil(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err = Load(path)
+	assert.NotNil(t, err, "a broken split file fails to load")
+
+	assert.Nil(t, Restore(backups[0], path))
+	run, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 3*time.Minute, run.Segments[0].PBTime, "restoring the newest backup gives the run before the last save")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/rs/zerolog/log"
+
+	"speedruntimer/journal"
+	"speedruntimer/splitfile"
+	"speedruntimer/timing/timer"
+)
+
+// loadRun reads the split file at path, offering to restore a backup if it can't be read.
+func (a *timerApp) loadRun(path string) {
+	run, err := splitfile.Load(path)
+	if err != nil {
+		log.Print("split load error")
+		log.Print(err.Error())
+
+		a.run = timer.DefaultRun()
+		a.offerRestoreBackup(path, err)
+		return
+	}
+	a.run = run
+}
+
+// saveRun writes the loaded run back to its split file, if it has one.
+func (a *timerApp) saveRun() {
+	if a.conf.LastSplitFile == "" || len(a.run.Segments) == 0 || a.run.Segments[0].Name == "" {
+		return // no run loaded
+	}
+
+	if err := splitfile.Save(a.run, a.conf.LastSplitFile, a.conf.SplitBackups); err != nil {
+		a.showError(err)
+	}
+}
+
+func (a *timerApp) offerRestoreBackup(path string, loadErr error) {
+	backups, err := splitfile.Backups(path)
+	if err != nil || len(backups) == 0 {
+		a.showError(loadErr)
+		return
+	}
+
+	var labels []string
+	for _, b := range backups {
+		labels = append(labels, b.Time.Format("2006-01-02 15:04:05"))
+	}
+	choice := widget.NewSelect(labels, nil)
+	choice.SetSelectedIndex(0)
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("The split file could not be read:\n%s\n\nRestore it from a backup?", loadErr)),
+		widget.NewForm(widget.NewFormItem("Backup from", choice)),
+	)
+
+	a.dialogwindow.Show()
+	dialog.ShowCustomConfirm("Restore Backup", "Restore", "Cancel", content, func(restore bool) {
+		a.dialogwindow.Hide()
+		if !restore {
+			return
+		}
+
+		if err := splitfile.Restore(backups[choice.SelectedIndex()], path); err != nil {
+			a.showError(err)
+			return
+		}
+		a.loadRun(path)
+		a.rebuildLayout()
+		a.restoreWindowSize()
+	}, a.dialogwindow)
+}
+
+// offerResume asks whether to carry on the attempt that was in progress when the app last closed.
+func (a *timerApp) offerResume() {
+	entry, err := journal.Load()
+	if err != nil {
+		log.Print("journal load error")
+		log.Print(err.Error())
+		return
+	}
+	if entry == nil {
+		return
+	}
+	if entry.SplitFile != a.conf.LastSplitFile || entry.State.Idle() {
+		journal.Clear()
+		return
+	}
+
+	message := fmt.Sprintf("An attempt was in progress when the timer closed, %d of %d splits in.\nResume it?",
+		entry.State.Segment, len(a.run.Segments))
+	if !entry.State.Start.IsZero() {
+		message += "\nThe time since then will count towards the attempt."
+	}
+
+	a.dialogwindow.Show()
+	dialog.ShowConfirm("Resume Attempt", message, func(resume bool) {
+		a.dialogwindow.Hide()
+		if !resume {
+			journal.Clear()
+			return
+		}
+
+		if err := a.timerLayout.Restore(entry.State); err != nil {
+			a.showError(err)
+			journal.Clear()
+		}
+	}, a.dialogwindow)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/stats"
+)
+
+// showStats opens a window of statistics about the loaded run's past attempts.
+func (a *timerApp) showStats() {
+	w := a.app.NewWindow("Statistics")
+	w.SetContent(container.NewAppTabs(
+		container.NewTabItem("Segments", a.segmentStats()),
+		container.NewTabItem("Resets", a.resetStats()),
+		container.NewTabItem("Golds", a.goldStats()),
+		container.NewTabItem("Trends", a.trendStats(w)),
+	))
+	w.Resize(fyne.NewSize(720, 400))
+	w.Show()
+}
+
+// segmentStats is a table of each segment's times across the run's history.
+func (a *timerApp) segmentStats() fyne.CanvasObject {
+	summaries := stats.Segments(a.run)
+
+	rows := make([][]string, len(summaries))
+	for i, s := range summaries {
+		rows[i] = []string{a.run.Segments[i].Name, fmt.Sprint(s.Count)}
+		for _, d := range []int64{s.Mean.Milliseconds(), s.Median.Milliseconds(), s.StdDev.Milliseconds(),
+			s.Q1.Milliseconds(), s.Q3.Milliseconds(), s.Min.Milliseconds()} {
+			if s.Count == 0 {
+				rows[i] = append(rows[i], "-")
+			} else {
+				rows[i] = append(rows[i], formatting.TimeFormatMilliseconds(d))
+			}
+		}
+	}
+
+	return newStatsTable([]string{"Segment", "Completed", "Mean", "Median", "Std Dev", "Q1", "Q3", "Best"}, rows)
+}
+
+// resetStats shows how often attempts reach each split, as a table and as a funnel chart.
+func (a *timerApp) resetStats() fyne.CanvasObject {
+	survivals := stats.Survivals(a.run)
+
+	var names []string
+	var chances []float64
+	rows := make([][]string, len(survivals))
+	for i, s := range survivals {
+		name := a.run.Segments[i].Name
+		names = append(names, name)
+		chances = append(chances, s.Chance)
+		rows[i] = []string{name, fmt.Sprint(s.Started), fmt.Sprint(s.Completed),
+			fmt.Sprintf("%.1f%%", s.Chance*100), fmt.Sprintf("%.1f%%", s.ResetRate*100)}
+	}
+
+	expected := "Never finished yet"
+	if e := stats.ExpectedAttempts(a.run); !math.IsInf(e, 1) {
+		expected = fmt.Sprintf("%.1f", e)
+	}
+
+	table := newStatsTable([]string{"Segment", "Started", "Completed", "Reached Split", "Reset Rate"}, rows)
+	summary := widget.NewLabel(fmt.Sprintf("%d attempts. Expected attempts to finish: %s", len(a.run.History), expected))
+	return container.NewBorder(summary, nil, nil, nil,
+		container.NewHSplit(table, newFunnel(names, chances)))
+}
+
+// newStatsTable shows rows of text under a bold header row, with each column as wide as its widest cell.
+func newStatsTable(headers []string, rows [][]string) *widget.Table {
+	cell := func(row, col int) string {
+		if row == 0 {
+			return headers[col]
+		}
+		return rows[row-1][col]
+	}
+
+	table := widget.NewTable(
+		func() (int, int) { return len(rows) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			label.TextStyle.Bold = id.Row == 0
+			label.SetText(cell(id.Row, id.Col))
+		},
+	)
+
+	padding := 4 * theme.Padding()
+	for col := range headers {
+		width := float32(0)
+		for row := 0; row <= len(rows); row++ {
+			size := fyne.MeasureText(cell(row, col), theme.TextSize(), fyne.TextStyle{Bold: row == 0})
+			if size.Width > width {
+				width = size.Width
+			}
+		}
+		table.SetColumnWidth(col, width+padding)
+	}
+	return table
+}
@@ -0,0 +1,220 @@
+package style
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Colors used by the timer on top of the usual fyne ones.
+const (
+	ColorNameAhead  fyne.ThemeColorName = "speedruntimer.ahead"
+	ColorNameBehind fyne.ThemeColorName = "speedruntimer.behind"
+	ColorNameGold   fyne.ThemeColorName = "speedruntimer.gold"
+)
+
+// Text sizes for individual layout components.
+const (
+	SizeNameTitle        fyne.ThemeSizeName = "speedruntimer.title"
+	SizeNameCategory     fyne.ThemeSizeName = "speedruntimer.category"
+	SizeNameTimer        fyne.ThemeSizeName = "speedruntimer.timer"
+	SizeNameSegmentTimer fyne.ThemeSizeName = "speedruntimer.segmenttimer"
+)
+
+// File is a theme as stored on disk. Anything left out falls back to the default theme.
+type File struct {
+	Background Color
+	Text       Color
+	Separator  Color
+	Ahead      Color
+	Behind     Color
+	Gold       Color
+
+	// Paths to font files. MonospaceFont is also used for the clocks, so their digits don't jitter.
+	Font          string
+	BoldFont      string
+	MonospaceFont string
+
+	// Sizes of text, keyed by component: "text", "title", "category", "timer" or "segmenttimer".
+	Sizes map[string]float32
+}
+
+// Color is an RGB or RGBA color written in hex, e.g. "#ff8800" or "#ff880080".
+type Color struct {
+	color.Color
+}
+
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if s == "" {
+		// unset, as written by MarshalJSON
+		c.Color = nil
+		return nil
+	}
+
+	var r, g, b, a uint8 = 0, 0, 0, 0xff
+	s = strings.TrimPrefix(s, "#")
+	switch len(s) {
+	case 6:
+		_, err := fmt.Sscanf(s, "%02x%02x%02x", &r, &g, &b)
+		if err != nil {
+			return fmt.Errorf("invalid color %q: %w", s, err)
+		}
+	case 8:
+		_, err := fmt.Sscanf(s, "%02x%02x%02x%02x", &r, &g, &b, &a)
+		if err != nil {
+			return fmt.Errorf("invalid color %q: %w", s, err)
+		}
+	default:
+		return fmt.Errorf("invalid color %q: expected #rrggbb or #rrggbbaa", s)
+	}
+
+	c.Color = color.NRGBA{R: r, G: g, B: b, A: a}
+	return nil
+}
+
+func (c Color) MarshalJSON() ([]byte, error) {
+	if c.Color == nil {
+		return []byte(`""`), nil
+	}
+	n := color.NRGBAModel.Convert(c.Color).(color.NRGBA)
+	return json.Marshal(fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A))
+}
+
+var defaultColors = map[fyne.ThemeColorName]color.Color{
+	ColorNameAhead:  color.NRGBA{R: 0x29, G: 0xcc, B: 0x54, A: 0xff},
+	ColorNameBehind: color.NRGBA{R: 0xcc, G: 0x36, B: 0x29, A: 0xff},
+	ColorNameGold:   color.NRGBA{R: 0xd8, G: 0xaf, B: 0x1f, A: 0xff},
+}
+
+var defaultSizes = map[fyne.ThemeSizeName]float32{
+	SizeNameTitle:        32,
+	SizeNameCategory:     24,
+	SizeNameTimer:        32,
+	SizeNameSegmentTimer: 24,
+}
+
+// Theme is a fyne.Theme built from a theme File, based on fyne's dark theme.
+type Theme struct {
+	colors map[fyne.ThemeColorName]color.Color
+	sizes  map[fyne.ThemeSizeName]float32
+
+	font, boldFont, monospaceFont fyne.Resource
+}
+
+// Default returns the theme used when none has been loaded.
+func Default() *Theme {
+	t, _ := New(&File{}) // cannot fail without font files to load
+	return t
+}
+
+func New(f *File) (*Theme, error) {
+	t := &Theme{
+		colors: map[fyne.ThemeColorName]color.Color{},
+		sizes:  map[fyne.ThemeSizeName]float32{},
+	}
+
+	for name, c := range defaultColors {
+		t.colors[name] = c
+	}
+	for name, c := range map[fyne.ThemeColorName]Color{
+		theme.ColorNameBackground: f.Background,
+		theme.ColorNameForeground: f.Text,
+		theme.ColorNameSeparator:  f.Separator,
+		ColorNameAhead:            f.Ahead,
+		ColorNameBehind:           f.Behind,
+		ColorNameGold:             f.Gold,
+	} {
+		if c.Color != nil {
+			t.colors[name] = c.Color
+		}
+	}
+
+	for name, s := range defaultSizes {
+		t.sizes[name] = s
+	}
+	for key, s := range f.Sizes {
+		name := fyne.ThemeSizeName("speedruntimer." + key)
+		if key == "text" {
+			name = theme.SizeNameText
+		} else if _, ok := defaultSizes[name]; !ok {
+			return nil, fmt.Errorf("unknown text size %q: expected text, title, category, timer or segmenttimer", key)
+		}
+		t.sizes[name] = s
+	}
+
+	var err error
+	for _, font := range []struct {
+		path string
+		into *fyne.Resource
+	}{
+		{f.Font, &t.font},
+		{f.BoldFont, &t.boldFont},
+		{f.MonospaceFont, &t.monospaceFont},
+	} {
+		if font.path == "" {
+			continue
+		}
+		*font.into, err = fyne.LoadResourceFromPath(font.path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func LoadFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{}
+	err = json.Unmarshal(data, f)
+	if err != nil {
+		return nil, err
+	}
+	return New(f)
+}
+
+func (t *Theme) Color(n fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	if c, ok := t.colors[n]; ok {
+		return c
+	}
+	// the timer is always dark, whatever the system preference
+	return theme.DefaultTheme().Color(n, theme.VariantDark)
+}
+
+func (t *Theme) Font(s fyne.TextStyle) fyne.Resource {
+	if s.Monospace && t.monospaceFont != nil {
+		return t.monospaceFont
+	}
+	if s.Bold && t.boldFont != nil {
+		return t.boldFont
+	}
+	if !s.Monospace && !s.Bold && t.font != nil {
+		return t.font
+	}
+	return theme.DefaultTheme().Font(s)
+}
+
+func (t *Theme) Icon(n fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(n)
+}
+
+func (t *Theme) Size(n fyne.ThemeSizeName) float32 {
+	if s, ok := t.sizes[n]; ok {
+		return s
+	}
+	return theme.DefaultTheme().Size(n)
+}
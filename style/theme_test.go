@@ -0,0 +1,58 @@
+package style
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestColorJSON(t *testing.T) {
+	var c Color
+	assert.Nil(t, json.Unmarshal([]byte(`"#ff8800"`), &c))
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0x88, B: 0x00, A: 0xff}, c.Color,
+		"colors without alpha are opaque")
+
+	assert.Nil(t, json.Unmarshal([]byte(`"#ff880080"`), &c))
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0x88, B: 0x00, A: 0x80}, c.Color,
+		"colors can have alpha")
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"orange"`), &c), "colors must be hex")
+
+	data, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `"#ff880080"`, string(data), "colors should be saved the way they are loaded")
+
+	data, err = json.Marshal(&File{Ahead: Color{color.NRGBA{R: 1, A: 0xff}}})
+	assert.Nil(t, err)
+	loaded := &File{}
+	assert.Nil(t, json.Unmarshal(data, loaded), "a saved theme file can be loaded again, unset colors and all")
+	assert.Nil(t, loaded.Gold.Color, "unset colors stay unset")
+}
+
+func TestNew(t *testing.T) {
+	f := &File{
+		Ahead: Color{color.NRGBA{R: 1, G: 2, B: 3, A: 0xff}},
+		Sizes: map[string]float32{"timer": 48, "text": 12},
+	}
+	th, err := New(f)
+	assert.Nil(t, err)
+
+	assert.Equal(t, f.Ahead.Color, th.Color(ColorNameAhead, theme.VariantDark),
+		"colors from the file are used")
+	assert.Equal(t, defaultColors[ColorNameGold], th.Color(ColorNameGold, theme.VariantDark),
+		"colors left out of the file use the defaults")
+
+	assert.Equal(t, float32(48), th.Size(SizeNameTimer), "component sizes from the file are used")
+	assert.Equal(t, float32(12), th.Size(theme.SizeNameText), `"text" sets the size of ordinary text`)
+	assert.Equal(t, float32(32), th.Size(SizeNameTitle), "sizes left out of the file use the defaults")
+
+	_, err = New(&File{Font: "/does/not/exist.ttf"})
+	assert.NotNil(t, err, "missing font files are an error")
+
+	_, err = New(&File{Sizes: map[string]float32{"timre": 48}})
+	assert.NotNil(t, err, "unknown size names are an error")
+}
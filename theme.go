@@ -0,0 +1,14 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+)
+
+// themeColor looks up a color in the current theme, for canvas objects
+// that don't do so themselves.
+func themeColor(name fyne.ThemeColorName) color.Color {
+	settings := fyne.CurrentApp().Settings()
+	return settings.Theme().Color(name, settings.ThemeVariant())
+}
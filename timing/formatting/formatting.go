@@ -5,6 +5,11 @@ import (
 )
 
 func TimeFormatMilliseconds(milliseconds int64) (out string) {
+	if milliseconds < 0 {
+		// before the start offset is run down, e.g. -00:01.500
+		return "-" + TimeFormatMilliseconds(-milliseconds)
+	}
+
 	// minutes, seconds, milliseconds
 	out = fmt.Sprintf("%02d:%02d.%03d", milliseconds/60000%60, milliseconds/1000%60, milliseconds%1000)
 	if milliseconds >= 3600000 {
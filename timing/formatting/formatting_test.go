@@ -11,6 +11,8 @@ func TestTimeFormat(t *testing.T) {
 		"If input less than an hour, don't show hours")
 	assert.Equal(t, TimeFormatMilliseconds(4000000), "01:06:40.000",
 		"If input more than an hour, show hours")
+	assert.Equal(t, TimeFormatMilliseconds(-1500), "-00:01.500",
+		"If input negative, show a minus sign")
 }
 
 func TestDeltaFormat(t *testing.T) {
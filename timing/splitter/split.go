@@ -8,8 +8,14 @@ import (
 type Split struct {
 	Name          string
 	ActiveRunTime time.Duration `json:"-"`
+	ActiveSegment time.Duration `json:"-"` // How long this segment took in the current run.
 	PBTime        time.Duration // Refers to the time in your PB run. Updated on run restart.
 	BestSegment   time.Duration
+	// GoldHistory holds every time the segment was completed faster than ever before, oldest first.
+	// The last one is the BestSegment, unless some were removed.
+	GoldHistory []Gold `json:",omitempty"`
+
+	gold bool
 
 	// Ideas:
 	// what about pb pace by this split?
@@ -20,12 +26,21 @@ type Split struct {
 	// what if every data point is stored in a file, and that data is analyzed to these smaller statistics on window open and timer reset?
 }
 
+// Gold is a best segment time, and when it was set.
+type Gold struct {
+	Time time.Duration
+	// Date is when the attempt that set the gold ended. It is zero for golds set before golds were recorded.
+	Date time.Time `json:",omitempty"`
+}
+
 func (s *Split) Split(at time.Duration, prev time.Duration) {
 	s.ActiveRunTime = at
+	s.ActiveSegment = s.ActiveRunTime - prev
 
-	segmentTime := s.ActiveRunTime - prev
-	if segmentTime < s.BestSegment {
-		s.BestSegment = segmentTime
+	// a BestSegment of 0 means the segment has never been completed before
+	s.gold = s.BestSegment == time.Duration(0) || s.ActiveSegment < s.BestSegment
+	if s.gold {
+		s.BestSegment = s.ActiveSegment
 	}
 }
 
@@ -34,6 +49,13 @@ func (s *Split) Restart(isPB bool) {
 		s.PBTime = s.ActiveRunTime
 	}
 	s.ActiveRunTime = time.Duration(0)
+	s.ActiveSegment = time.Duration(0)
+	s.gold = false
+}
+
+// IsGold returns if the segment was completed faster than ever before in the current run.
+func (s *Split) IsGold() bool {
+	return s.gold
 }
 
 // IsGreen returns if the split's time in the current run is better than its time in your previous PB run.
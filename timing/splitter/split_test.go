@@ -32,6 +32,23 @@ func TestSplit(t *testing.T) {
 		"Split() should set BestSegment on a best segment, even if not green")
 }
 
+func TestIsGold(t *testing.T) {
+	split := Split{Name: "Fake Split 1"}
+
+	split.Split(randDurationWithMax(day)+1, time.Duration(0))
+	assert.True(t, split.IsGold(),
+		"IsGold() should return true the first time a segment is completed")
+	assert.Equal(t, split.ActiveSegment, split.BestSegment,
+		"Split() should set BestSegment the first time a segment is completed")
+
+	split.Restart(false)
+	assert.False(t, split.IsGold(), "Restart() should clear the gold")
+
+	split.Split(split.BestSegment+randDurationWithMax(day)+1, time.Duration(0))
+	assert.False(t, split.IsGold(),
+		"IsGold() should return false when the segment is slower than the best segment")
+}
+
 func TestRestart(t *testing.T) {
 	initialPBTime := randDurationWithMax(day)
 	// TODO: perhaps just have a single split time, and let running the test a lot hit these two cases roughly equally often
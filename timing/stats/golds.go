@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/timer"
+)
+
+// SuspiciousGold is a gold that looks like it came from a timing mistake rather than a good segment.
+type SuspiciousGold struct {
+	// Segment is the index of the segment, and Gold the index of the gold in its GoldHistory.
+	Segment, Gold int
+	Reason        string
+}
+
+// minOutlierTimes is how many past times a segment needs before its outliers can be picked out.
+const minOutlierTimes = 4
+
+// minOutlierSpread divides the median to give the smallest gap between the quartiles and the fences.
+const minOutlierSpread = 10
+
+// outlierFences returns the times below and above which a time is far out of the ordinary for the summary.
+func outlierFences(s Summary) (low, high time.Duration, ok bool) {
+	if s.Count < minOutlierTimes {
+		return 0, 0, false
+	}
+	spread := 3 * (s.Q3 - s.Q1)
+	// times that are nearly all the same would make every other time an outlier
+	if min := s.Median / minOutlierSpread; spread < min {
+		spread = min
+	}
+	return s.Q1 - spread, s.Q3 + spread, true
+}
+
+// SuspiciousGolds finds golds that are far faster than the segment usually is,
+// or that next to an unusually slow segment in the same attempt, which happens when a split
+// is pressed too early or too late. Only golds that are the segment's best time are listed,
+// as removing a gold that has since been beaten wouldn't change anything.
+func SuspiciousGolds(run *timer.Run) (out []SuspiciousGold) {
+	summaries := Segments(run)
+
+	for i, s := range run.Segments {
+		for g, gold := range s.GoldHistory {
+			if !soleBest(s.GoldHistory, g) {
+				continue
+			}
+			if reason := suspicion(run, summaries, i, gold); reason != "" {
+				out = append(out, SuspiciousGold{Segment: i, Gold: g, Reason: reason})
+			}
+		}
+	}
+	return out
+}
+
+// soleBest reports whether golds[idx] is faster than every other gold, so that removing it changes the best time.
+func soleBest(golds []timer.Gold, idx int) bool {
+	for i, g := range golds {
+		if i != idx && g.Time <= golds[idx].Time {
+			return false
+		}
+	}
+	return true
+}
+
+func suspicion(run *timer.Run, summaries []Summary, segment int, gold timer.Gold) string {
+	summary := summaries[segment]
+	if low, _, ok := outlierFences(summary); ok && gold.Time < low || summary.Count > 0 && gold.Time < summary.Median/2 {
+		return fmt.Sprintf("far faster than usual (median %s)", formatting.TimeFormatMilliseconds(summary.Median.Milliseconds()))
+	}
+
+	attempt, ok := goldAttempt(run, segment, gold)
+	if !ok {
+		return ""
+	}
+	for _, neighbour := range []struct {
+		idx    int
+		reason string
+	}{
+		{segment - 1, "the segment before was unusually slow, so its split may have been late"},
+		{segment + 1, "the segment after was unusually slow, so this split may have been early"},
+	} {
+		t, ok := attempt.SegmentTime(neighbour.idx)
+		if !ok {
+			continue
+		}
+		if _, high, ok := outlierFences(summaries[neighbour.idx]); ok && t > high {
+			return neighbour.reason
+		}
+	}
+	return ""
+}
+
+// goldAttempt finds the attempt that set the gold.
+func goldAttempt(run *timer.Run, segment int, gold timer.Gold) (timer.Attempt, bool) {
+	if gold.Date.IsZero() {
+		return timer.Attempt{}, false
+	}
+	for _, a := range run.History {
+		if t, ok := a.SegmentTime(segment); ok && t == gold.Time && a.Ended.Equal(gold.Date) {
+			return a, true
+		}
+	}
+	return timer.Attempt{}, false
+}
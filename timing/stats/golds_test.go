@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestSuspiciousGolds(t *testing.T) {
+	run := &timer.Run{Segments: []*timer.Split{{}, {}}}
+	for i := 0; i < 8; i++ {
+		first := time.Duration(20+i) * time.Second
+		run.History = append(run.History, timer.Attempt{Splits: []time.Duration{first, first + 20*time.Second}})
+	}
+
+	// the first split was pressed 10s early, making the second segment 10s slower
+	early := time.Unix(1000, 0)
+	run.History = append(run.History, timer.Attempt{Ended: early, Splits: []time.Duration{12 * time.Second, 50 * time.Second}})
+	run.Segments[0].GoldHistory = []timer.Gold{{Time: 20 * time.Second}, {Time: 12 * time.Second, Date: early}}
+	run.Segments[1].GoldHistory = []timer.Gold{{Time: 5 * time.Second}}
+
+	suspicious := SuspiciousGolds(run)
+	assert.Len(t, suspicious, 2)
+	assert.Equal(t, 0, suspicious[0].Segment)
+	assert.Equal(t, 1, suspicious[0].Gold, "a gold next to an unusually slow segment is suspicious")
+	assert.Contains(t, suspicious[0].Reason, "early")
+	assert.Equal(t, 1, suspicious[1].Segment)
+	assert.Contains(t, suspicious[1].Reason, "faster than usual", "a gold far faster than usual is suspicious")
+
+	run.Segments[1].GoldHistory = append(run.Segments[1].GoldHistory, timer.Gold{Time: 4 * time.Second})
+	suspicious = SuspiciousGolds(run)
+	assert.Len(t, suspicious, 2, "a gold that has since been beaten isn't listed, as removing it changes nothing")
+	assert.Equal(t, 1, suspicious[1].Segment)
+	assert.Equal(t, 1, suspicious[1].Gold)
+}
+
+func TestOutlierFences(t *testing.T) {
+	same := Summarize([]time.Duration{20 * time.Second, 20 * time.Second, 20 * time.Second, 20 * time.Second})
+	low, high, ok := outlierFences(same)
+	assert.True(t, ok)
+	assert.Equal(t, 18*time.Second, low, "identical times still leave room either side")
+	assert.Equal(t, 22*time.Second, high)
+}
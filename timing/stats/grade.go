@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"speedruntimer/timing/timer"
+)
+
+// Grade is a letter rating of a segment time against the segment's past times, from S (best) to D.
+type Grade string
+
+const (
+	GradeS Grade = "S"
+	GradeA Grade = "A"
+	GradeB Grade = "B"
+	GradeC Grade = "C"
+	GradeD Grade = "D"
+)
+
+// Grades lists every grade from best to worst.
+var Grades = []Grade{GradeS, GradeA, GradeB, GradeC, GradeD}
+
+// gradeThresholds are the highest rank each grade allows; anything slower is a D.
+var gradeThresholds = []struct {
+	grade Grade
+	rank  float64
+}{
+	{GradeS, 0.1},
+	{GradeA, 0.3},
+	{GradeB, 0.6},
+	{GradeC, 0.85},
+}
+
+// adjustWeight is how far the previous segments move the thresholds when grades are adjusted.
+const adjustWeight = 0.5
+
+// Rank returns the fraction of the past times that were faster than t, counting ties as half.
+// 0 is faster than every past time, and 1 slower than every one.
+func Rank(past []time.Duration, t time.Duration) float64 {
+	var faster float64
+	for _, p := range past {
+		if p < t {
+			faster++
+		} else if p == t {
+			faster += 0.5
+		}
+	}
+	return faster / float64(len(past))
+}
+
+// GradeRank returns the grade for a segment time of the given Rank.
+func GradeRank(rank float64) Grade {
+	for _, th := range gradeThresholds {
+		if rank <= th.rank {
+			return th.grade
+		}
+	}
+	return GradeD
+}
+
+// GradeSegments grades each of an attempt's segment times against the run's history,
+// which shouldn't include the attempt. Segments with no past times are left ungraded ("").
+//
+// With adjust, each grade is relative to how the attempt has gone so far: after good segments
+// a segment has to be better to earn the same grade, and after bad ones it can be worse.
+func GradeSegments(run *timer.Run, segments []time.Duration, adjust bool) []Grade {
+	out := make([]Grade, len(segments))
+	var rankSum float64
+	var ranked int
+	for i, t := range segments {
+		if i >= len(run.Segments) {
+			break
+		}
+		past := SegmentTimes(run, i)
+		if len(past) == 0 {
+			continue
+		}
+
+		rank := Rank(past, t)
+		adjusted := rank
+		if adjust && ranked > 0 {
+			adjusted += adjustWeight * (0.5 - rankSum/float64(ranked))
+		}
+
+		// a gold is always an S, however the run has gone; being faster than the history isn't enough,
+		// as it may not hold every attempt that set a best segment
+		if best := run.Segments[i].BestSegment; best != 0 && t <= best {
+			out[i] = GradeS
+		} else {
+			out[i] = GradeRank(adjusted)
+		}
+		rankSum += rank
+		ranked++
+	}
+	return out
+}
+
+// AttemptSegments returns the segment times of the attempt in progress so far.
+func AttemptSegments(t timer.Timer, run *timer.Run) (out []time.Duration) {
+	for i := 0; i < t.CurrentSegment() && i < len(run.Segments); i++ {
+		out = append(out, run.Segments[i].ActiveSegment)
+	}
+	return out
+}
+
+// GradeSummary counts the grades, e.g. "2S 1A 3C". Ungraded segments are left out.
+func GradeSummary(grades []Grade) string {
+	counts := map[Grade]int{}
+	for _, g := range grades {
+		counts[g]++
+	}
+
+	var parts []string
+	for _, g := range Grades {
+		if counts[g] > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", counts[g], g))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+// gradedRun has ten past attempts at two segments, taking 10s to 19s each.
+func gradedRun() *timer.Run {
+	run := &timer.Run{Segments: []*timer.Split{{}, {}, {}}}
+	for i := 0; i < 10; i++ {
+		first := time.Duration(10+i) * time.Second
+		run.History = append(run.History, timer.Attempt{Splits: []time.Duration{first, 2 * first}})
+	}
+	return run
+}
+
+func TestRank(t *testing.T) {
+	past := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second}
+	assert.Equal(t, 0.0, Rank(past, 0), "faster than every past time")
+	assert.Equal(t, 0.625, Rank(past, 3*time.Second), "ties count as half")
+	assert.Equal(t, 1.0, Rank(past, 5*time.Second), "slower than every past time")
+}
+
+func TestGradeSegments(t *testing.T) {
+	run := gradedRun()
+
+	grades := GradeSegments(run, []time.Duration{time.Second, 15 * time.Second, time.Second}, false)
+	assert.Equal(t, []Grade{GradeS, GradeB, ""}, grades, "segments are graded against their past times")
+
+	grades = GradeSegments(run, []time.Duration{30 * time.Second, 13 * time.Second}, true)
+	assert.Equal(t, GradeD, grades[0])
+	assert.Equal(t, GradeS, grades[1], "after a bad segment, the thresholds are eased")
+
+	grades = GradeSegments(run, []time.Duration{time.Second, 11500 * time.Millisecond}, true)
+	assert.Equal(t, GradeB, grades[1], "after a good segment, the thresholds are tightened")
+
+	run.Segments[1].BestSegment = 5 * time.Second
+	grades = GradeSegments(run, []time.Duration{time.Second, 9 * time.Second}, true)
+	assert.Equal(t, GradeA, grades[1], "faster than every past time is not a gold, so the thresholds still apply")
+	grades = GradeSegments(run, []time.Duration{time.Second, 5 * time.Second}, true)
+	assert.Equal(t, GradeS, grades[1], "a gold is always an S")
+}
+
+func TestGradeSummary(t *testing.T) {
+	assert.Equal(t, "2S 1C", GradeSummary([]Grade{GradeS, GradeC, "", GradeS}))
+	assert.Equal(t, "", GradeSummary(nil))
+}
@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"speedruntimer/timing/timer"
+)
+
+// AttemptSummary is what the history browser shows about one attempt.
+type AttemptSummary struct {
+	// Index is the attempt's index in the run's History.
+	Index   int
+	Attempt timer.Attempt
+
+	// Duration is the wall clock time the attempt lasted, pauses and all.
+	Duration time.Duration
+	Final    time.Duration
+	Finished bool
+	// PB is whether the attempt set a PB, and Golds how many golds it set.
+	PB    bool
+	Golds int
+}
+
+// Attempts summarizes every attempt in the run's history, oldest first.
+func Attempts(run *timer.Run) []AttemptSummary {
+	out := make([]AttemptSummary, len(run.History))
+	for i, a := range run.History {
+		out[i] = AttemptSummary{Index: i, Attempt: a}
+		if !a.Began.IsZero() {
+			out[i].Duration = a.Ended.Sub(a.Began)
+		}
+		out[i].Final, out[i].Finished = a.FinalTime(run)
+
+		for _, pb := range run.PBHistory {
+			if !pb.Date.IsZero() && pb.Date.Equal(a.Ended) {
+				out[i].PB = true
+			}
+		}
+		for _, s := range run.Segments {
+			for _, g := range s.GoldHistory {
+				if !g.Date.IsZero() && g.Date.Equal(a.Ended) {
+					out[i].Golds++
+				}
+			}
+		}
+	}
+	return out
+}
+
+// AttemptSort is what attempts are sorted by.
+type AttemptSort int
+
+const (
+	SortByDate AttemptSort = iota
+	SortByDuration
+	SortByReached
+	SortByFinalTime
+)
+
+// SortAttempts sorts the attempts in place. Unfinished attempts go last when sorting by final time.
+func SortAttempts(attempts []AttemptSummary, by AttemptSort, descending bool) {
+	less := func(a, b AttemptSummary) bool {
+		switch by {
+		case SortByDuration:
+			return a.Duration < b.Duration
+		case SortByReached:
+			return a.Attempt.Reached() < b.Attempt.Reached()
+		case SortByFinalTime:
+			return a.Final < b.Final
+		}
+		return a.Index < b.Index
+	}
+
+	sort.SliceStable(attempts, func(i, j int) bool {
+		if by == SortByFinalTime && attempts[i].Finished != attempts[j].Finished {
+			return attempts[i].Finished
+		}
+		if descending {
+			return less(attempts[j], attempts[i])
+		}
+		return less(attempts[i], attempts[j])
+	})
+}
+
+// AttemptFilter picks out attempts to show. The zero value matches every attempt.
+type AttemptFilter struct {
+	FinishedOnly bool
+	PBsOnly      bool
+	GoldsOnly    bool
+	// Since leaves out attempts that began before it, unless it is zero.
+	Since time.Time
+}
+
+func (f AttemptFilter) Match(a AttemptSummary) bool {
+	return (!f.FinishedOnly || a.Finished) &&
+		(!f.PBsOnly || a.PB) &&
+		(!f.GoldsOnly || a.Golds > 0) &&
+		(f.Since.IsZero() || !a.Attempt.Began.Before(f.Since))
+}
+
+// FilterAttempts returns the attempts that match the filter, in the same order.
+func FilterAttempts(attempts []AttemptSummary, f AttemptFilter) (out []AttemptSummary) {
+	for _, a := range attempts {
+		if f.Match(a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
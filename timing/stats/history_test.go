@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func historyRun() *timer.Run {
+	day := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pb := day.Add(time.Hour)
+	return &timer.Run{
+		Segments: []*timer.Split{{GoldHistory: []timer.Gold{{Time: 10 * time.Second, Date: pb}}}, {}},
+		History: []timer.Attempt{
+			{Began: day, Ended: day.Add(10 * time.Second), Splits: []time.Duration{10 * time.Second}},
+			{Began: pb.Add(-40 * time.Second), Ended: pb, Splits: []time.Duration{10 * time.Second, 40 * time.Second}},
+			{Began: day.Add(2 * time.Hour), Ended: day.Add(2*time.Hour + time.Minute), Splits: []time.Duration{20 * time.Second, time.Minute}},
+		},
+		PBHistory: []timer.PBRecord{{Date: pb, Splits: []time.Duration{10 * time.Second, 40 * time.Second}}},
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	attempts := Attempts(historyRun())
+	assert.Len(t, attempts, 3)
+	assert.Equal(t, 10*time.Second, attempts[0].Duration)
+	assert.False(t, attempts[0].Finished, "an attempt reset early is unfinished")
+	assert.True(t, attempts[1].PB, "the attempt that set the PB is marked")
+	assert.Equal(t, 1, attempts[1].Golds, "the attempt's golds are counted")
+	assert.Equal(t, time.Minute, attempts[2].Final)
+}
+
+func TestSortAttempts(t *testing.T) {
+	attempts := Attempts(historyRun())
+
+	SortAttempts(attempts, SortByFinalTime, false)
+	assert.Equal(t, []int{1, 2, 0}, indices(attempts), "fastest first, unfinished last")
+
+	SortAttempts(attempts, SortByFinalTime, true)
+	assert.Equal(t, []int{2, 1, 0}, indices(attempts), "slowest first, unfinished still last")
+
+	SortAttempts(attempts, SortByDate, true)
+	assert.Equal(t, []int{2, 1, 0}, indices(attempts), "newest first")
+}
+
+func TestFilterAttempts(t *testing.T) {
+	attempts := Attempts(historyRun())
+
+	assert.Equal(t, []int{1, 2}, indices(FilterAttempts(attempts, AttemptFilter{FinishedOnly: true})))
+	assert.Equal(t, []int{1}, indices(FilterAttempts(attempts, AttemptFilter{PBsOnly: true})))
+	assert.Equal(t, []int{1, 2}, indices(FilterAttempts(attempts, AttemptFilter{Since: attempts[1].Attempt.Began})),
+		"attempts that began before Since are left out")
+	assert.Len(t, FilterAttempts(attempts, AttemptFilter{}), 3, "the zero filter matches everything")
+}
+
+func indices(attempts []AttemptSummary) (out []int) {
+	for _, a := range attempts {
+		out = append(out, a.Index)
+	}
+	return out
+}
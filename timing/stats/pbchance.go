@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"math/rand"
+	"time"
+
+	"speedruntimer/timing/timer"
+)
+
+// FinishChance estimates the chance of finishing the run in under target, for an attempt at run time at
+// that has completed the segments before from and spent elapsed in segment from so far. It simulates the
+// rest of the attempt samples times, drawing each remaining segment's time at random from its past times.
+//
+// Past times of segment from shorter than elapsed are left out, as the attempt is already past them.
+// If every past time is shorter, the segment is taken to end now, so the estimate is the best case.
+//
+// It returns false if a remaining segment has never been completed, as there is nothing to draw from.
+func FinishChance(run *timer.Run, from int, at, elapsed, target time.Duration, samples int, rng *rand.Rand) (float64, bool) {
+	if from >= len(run.Segments) {
+		if at < target {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	var remaining [][]time.Duration
+	for i := from; i < len(run.Segments); i++ {
+		past := SegmentTimes(run, i)
+		if len(past) == 0 {
+			return 0, false
+		}
+		remaining = append(remaining, past)
+	}
+	remaining[0] = atLeast(remaining[0], elapsed)
+
+	under := 0
+	for n := 0; n < samples; n++ {
+		total := at
+		for _, past := range remaining {
+			total += past[rng.Intn(len(past))]
+		}
+		if total < target {
+			under++
+		}
+	}
+	return float64(under) / float64(samples), true
+}
+
+// atLeast returns the times that are no shorter than min, or just min if there are none.
+func atLeast(times []time.Duration, min time.Duration) []time.Duration {
+	var out []time.Duration
+	for _, t := range times {
+		if t >= min {
+			out = append(out, t)
+		}
+	}
+	if len(out) == 0 {
+		return []time.Duration{min}
+	}
+	return out
+}
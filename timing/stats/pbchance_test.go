@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestFinishChance(t *testing.T) {
+	run := &timer.Run{
+		Segments: []*timer.Split{{}, {}},
+		History: []timer.Attempt{
+			{Splits: []time.Duration{10 * time.Second, 20 * time.Second}},
+			{Splits: []time.Duration{10 * time.Second, 30 * time.Second}},
+		},
+	}
+	rng := rand.New(rand.NewSource(1))
+
+	chance, ok := FinishChance(run, 1, 10*time.Second, 0, 25*time.Second, 10000, rng)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, chance, 0.05, "the last segment takes under 15s in half of the past attempts")
+
+	chance, _ = FinishChance(run, 0, 0, 0, time.Hour, 100, rng)
+	assert.Equal(t, 1.0, chance, "every simulated attempt beats an easy target")
+
+	chance, _ = FinishChance(run, 2, 20*time.Second, 0, 25*time.Second, 100, rng)
+	assert.Equal(t, 1.0, chance, "a finished attempt under the target is certain")
+
+	chance, _ = FinishChance(run, 1, 10*time.Second, 12*time.Second, 25*time.Second, 100, rng)
+	assert.Equal(t, 0.0, chance, "past times the segment has already run longer than are left out")
+
+	chance, _ = FinishChance(run, 1, 10*time.Second, 25*time.Second, time.Minute, 100, rng)
+	assert.Equal(t, 1.0, chance, "a segment slower than every past time is taken to end now")
+	chance, _ = FinishChance(run, 1, 10*time.Second, 25*time.Second, 30*time.Second, 100, rng)
+	assert.Equal(t, 0.0, chance, "a segment slower than every past time is taken to end now")
+
+	run.History = run.History[:0]
+	_, ok = FinishChance(run, 0, 0, 0, time.Hour, 100, rng)
+	assert.False(t, ok, "there is no estimate without past times to draw from")
+}
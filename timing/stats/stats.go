@@ -0,0 +1,85 @@
+// Package stats summarizes the attempts recorded in a run's history.
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"speedruntimer/timing/timer"
+)
+
+// Summary describes a set of times, e.g. every completion of one segment.
+type Summary struct {
+	Count        int
+	Mean, Median time.Duration
+	StdDev       time.Duration
+	// Q1 and Q3 are the first and third quartiles; half the times lie between them.
+	Q1, Q3   time.Duration
+	Min, Max time.Duration
+}
+
+// Summarize returns a summary of the times. Every field is 0 if there are none.
+func Summarize(times []time.Duration) Summary {
+	if len(times) == 0 {
+		return Summary{}
+	}
+
+	sorted := append([]time.Duration(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum float64
+	for _, t := range sorted {
+		sum += float64(t)
+	}
+	mean := sum / float64(len(sorted))
+
+	var squares float64
+	for _, t := range sorted {
+		squares += (float64(t) - mean) * (float64(t) - mean)
+	}
+
+	return Summary{
+		Count:  len(sorted),
+		Mean:   time.Duration(mean),
+		Median: Quantile(sorted, 0.5),
+		StdDev: time.Duration(math.Sqrt(squares / float64(len(sorted)))),
+		Q1:     Quantile(sorted, 0.25),
+		Q3:     Quantile(sorted, 0.75),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// Quantile returns the time that q of the sorted times are at or under, for q between 0 and 1,
+// interpolating between the two nearest times.
+func Quantile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return sorted[lower] + time.Duration(frac*float64(sorted[upper]-sorted[lower]))
+}
+
+// SegmentTimes returns how long the segment at idx took in every attempt that completed it, oldest first.
+func SegmentTimes(run *timer.Run, idx int) (out []time.Duration) {
+	for _, a := range run.History {
+		if t, ok := a.SegmentTime(idx); ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Segments returns a summary of each segment's times across the run's history.
+func Segments(run *timer.Run) []Summary {
+	out := make([]Summary, len(run.Segments))
+	for i := range run.Segments {
+		out[i] = Summarize(SegmentTimes(run, i))
+	}
+	return out
+}
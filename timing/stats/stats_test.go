@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]time.Duration{4 * time.Second, 2 * time.Second, 8 * time.Second, 6 * time.Second, 10 * time.Second})
+	assert.Equal(t, 5, s.Count)
+	assert.Equal(t, 6*time.Second, s.Mean)
+	assert.Equal(t, 6*time.Second, s.Median, "the median is the middle time")
+	assert.Equal(t, 4*time.Second, s.Q1)
+	assert.Equal(t, 8*time.Second, s.Q3)
+	assert.Equal(t, 2*time.Second, s.Min)
+	assert.Equal(t, 10*time.Second, s.Max)
+	assert.InDelta(t, float64(2828427124), float64(s.StdDev), float64(time.Millisecond))
+
+	assert.Equal(t, Summary{}, Summarize(nil), "nothing to summarize gives an empty summary")
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []time.Duration{time.Second, 2 * time.Second}
+	assert.Equal(t, 1500*time.Millisecond, Quantile(sorted, 0.5), "quantiles interpolate between times")
+	assert.Equal(t, time.Second, Quantile(sorted, 0))
+	assert.Equal(t, 2*time.Second, Quantile(sorted, 1))
+}
+
+func TestSegments(t *testing.T) {
+	run := &timer.Run{
+		Segments: []*timer.Split{{}, {}},
+		History: []timer.Attempt{
+			{Splits: []time.Duration{10 * time.Second, 30 * time.Second}},
+			{Splits: []time.Duration{12 * time.Second}},
+		},
+	}
+
+	segments := Segments(run)
+	assert.Equal(t, 2, segments[0].Count, "every attempt reaching a split counts towards it")
+	assert.Equal(t, 11*time.Second, segments[0].Mean)
+	assert.Equal(t, 1, segments[1].Count, "attempts reset early don't count towards later splits")
+	assert.Equal(t, 20*time.Second, segments[1].Mean)
+}
@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"math"
+
+	"speedruntimer/timing/timer"
+)
+
+// Survival describes how attempts fared at one segment.
+type Survival struct {
+	// Started is how many attempts got as far as the segment, and Completed how many finished it.
+	Started, Completed int
+	// Chance is the fraction of all attempts that completed the segment, i.e. reached its split.
+	Chance float64
+	// ResetRate is the fraction of attempts that started the segment and were reset during it.
+	ResetRate float64
+}
+
+// Survivals returns how attempts fared at each segment, across the run's history.
+func Survivals(run *timer.Run) []Survival {
+	out := make([]Survival, len(run.Segments))
+	for _, a := range run.History {
+		for i := range out {
+			if a.Reached() >= i {
+				out[i].Started++
+			}
+			if a.Reached() > i {
+				out[i].Completed++
+			}
+		}
+	}
+
+	for i := range out {
+		if len(run.History) > 0 {
+			out[i].Chance = float64(out[i].Completed) / float64(len(run.History))
+		}
+		if out[i].Started > 0 {
+			out[i].ResetRate = 1 - float64(out[i].Completed)/float64(out[i].Started)
+		}
+	}
+	return out
+}
+
+// ExpectedAttempts returns how many attempts it takes on average to finish the run,
+// going by how often it has been finished so far. It is +Inf if it has never been finished.
+func ExpectedAttempts(run *timer.Run) float64 {
+	survivals := Survivals(run)
+	finishChance := survivals[len(survivals)-1].Chance
+	if finishChance == 0 {
+		return math.Inf(1)
+	}
+	return 1 / finishChance
+}
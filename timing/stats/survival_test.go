@@ -0,0 +1,34 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestSurvivals(t *testing.T) {
+	run := &timer.Run{
+		Segments: []*timer.Split{{}, {}},
+		History: []timer.Attempt{
+			{},
+			{Splits: []time.Duration{time.Second}},
+			{Splits: []time.Duration{time.Second, 2 * time.Second}},
+			{Splits: []time.Duration{time.Second, 2 * time.Second}},
+		},
+	}
+
+	survivals := Survivals(run)
+	assert.Equal(t, Survival{Started: 4, Completed: 3, Chance: 0.75, ResetRate: 0.25}, survivals[0])
+	assert.Equal(t, 3, survivals[1].Started, "only attempts that completed the first segment start the second")
+	assert.Equal(t, 0.5, survivals[1].Chance)
+	assert.InDelta(t, 1.0/3, survivals[1].ResetRate, 1e-9)
+
+	assert.Equal(t, 2.0, ExpectedAttempts(run), "half of the attempts finish")
+
+	run.History = run.History[:2]
+	assert.True(t, math.IsInf(ExpectedAttempts(run), 1), "a run never finished takes forever")
+}
@@ -0,0 +1,149 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"speedruntimer/timing/timer"
+)
+
+// Point is one value of a series over time.
+type Point struct {
+	Date  time.Time
+	Value time.Duration
+}
+
+// DayCount is how many attempts were started on one day.
+type DayCount struct {
+	Day      time.Time // midnight at the start of the day, in local time
+	Attempts int
+}
+
+// PBProgression returns the final time of each dated PB, oldest first.
+func PBProgression(run *timer.Run) (out []Point) {
+	for _, pb := range run.PBHistory {
+		if !pb.Date.IsZero() {
+			out = append(out, Point{pb.Date, pb.FinalTime()})
+		}
+	}
+	return out
+}
+
+// RollingAverage returns, for each finished attempt, the average final time
+// of it and up to window-1 finished attempts before it.
+func RollingAverage(run *timer.Run, window int) []Point {
+	var finals []Point
+	for _, a := range run.History {
+		if final, ok := a.FinalTime(run); ok {
+			finals = append(finals, Point{a.Ended, final})
+		}
+	}
+	return rolling(finals, window)
+}
+
+// SegmentRollingAverage returns, for each attempt that completed the segment at idx,
+// the average of its time and up to window-1 earlier times for the segment.
+func SegmentRollingAverage(run *timer.Run, idx, window int) []Point {
+	var times []Point
+	for _, a := range run.History {
+		if t, ok := a.SegmentTime(idx); ok {
+			times = append(times, Point{a.Ended, t})
+		}
+	}
+	return rolling(times, window)
+}
+
+func rolling(points []Point, window int) []Point {
+	if window < 1 {
+		window = 1 // an average of nothing would divide by zero
+	}
+	out := make([]Point, len(points))
+	var sum time.Duration
+	for i, p := range points {
+		sum += p.Value
+		if i >= window {
+			sum -= points[i-window].Value
+		}
+		n := i + 1
+		if n > window {
+			n = window
+		}
+		out[i] = Point{p.Date, sum / time.Duration(n)}
+	}
+	return out
+}
+
+// GoldsOverTime returns each dated gold of the segment at idx, oldest first.
+func GoldsOverTime(run *timer.Run, idx int) (out []Point) {
+	for _, g := range run.Segments[idx].GoldHistory {
+		if !g.Date.IsZero() {
+			out = append(out, Point{g.Date, g.Time})
+		}
+	}
+	return out
+}
+
+// AttemptsPerDay counts the attempts started on each day that had any, oldest first.
+func AttemptsPerDay(run *timer.Run) (out []DayCount) {
+	counts := map[time.Time]int{}
+	for _, a := range run.History {
+		if a.Began.IsZero() {
+			continue
+		}
+		local := a.Began.Local()
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+		counts[day]++
+	}
+
+	for day, n := range counts {
+		out = append(out, DayCount{day, n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Day.Before(out[j].Day) })
+	return out
+}
+
+// WriteTrendsCSV writes every trend series as CSV, one row per value:
+// the series, the segment it is for (blank for the whole run), the date, and the value.
+// Times are written in milliseconds.
+func WriteTrendsCSV(w io.Writer, run *timer.Run, window int) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"series", "segment", "date", "value"}); err != nil {
+		return err
+	}
+
+	writePoints := func(series, segment string, points []Point) error {
+		for _, p := range points {
+			row := []string{series, segment, p.Date.Format(time.RFC3339), fmt.Sprint(p.Value.Milliseconds())}
+			if err := out.Write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := writePoints("pb", "", PBProgression(run)); err != nil {
+		return err
+	}
+	if err := writePoints("rolling_average", "", RollingAverage(run, window)); err != nil {
+		return err
+	}
+	for i, s := range run.Segments {
+		if err := writePoints("segment_rolling_average", s.Name, SegmentRollingAverage(run, i, window)); err != nil {
+			return err
+		}
+		if err := writePoints("gold", s.Name, GoldsOverTime(run, i)); err != nil {
+			return err
+		}
+	}
+	for _, d := range AttemptsPerDay(run) {
+		if err := out.Write([]string{"attempts_per_day", "", d.Day.Format("2006-01-02"), fmt.Sprint(d.Attempts)}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"speedruntimer/timing/timer"
+)
+
+func TestRollingAverage(t *testing.T) {
+	run := &timer.Run{Segments: []*timer.Split{{}}}
+	for _, final := range []time.Duration{10, 20, 30, 40} {
+		run.History = append(run.History, timer.Attempt{Splits: []time.Duration{final * time.Second}})
+	}
+	run.History = append(run.History, timer.Attempt{}) // unfinished, left out
+
+	averages := RollingAverage(run, 2)
+	assert.Len(t, averages, 4)
+	assert.Equal(t, 10*time.Second, averages[0].Value, "the first average is of one attempt")
+	assert.Equal(t, 15*time.Second, averages[1].Value)
+	assert.Equal(t, 35*time.Second, averages[3].Value, "only the window's attempts are averaged")
+
+	averages = RollingAverage(run, 0)
+	assert.Equal(t, 40*time.Second, averages[3].Value, "a window under one averages each attempt alone")
+}
+
+func TestAttemptsPerDay(t *testing.T) {
+	day := time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)
+	run := &timer.Run{
+		Segments: []*timer.Split{{}},
+		History: []timer.Attempt{
+			{Began: day.Add(24 * time.Hour)},
+			{Began: day},
+			{Began: day.Add(time.Hour)},
+			{}, // from before attempts were dated
+		},
+	}
+
+	counts := AttemptsPerDay(run)
+	assert.Equal(t, []DayCount{
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), 2},
+		{time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local), 1},
+	}, counts)
+}
+
+func TestWriteTrendsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteTrendsCSV(&buf, historyRun(), 10))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, "series,segment,date,value", lines[0])
+	assert.Contains(t, lines, "pb,,2024-01-01T13:00:00Z,40000", "PBs are written in milliseconds")
+	assert.Contains(t, lines[len(lines)-1], "attempts_per_day")
+}
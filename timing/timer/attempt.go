@@ -0,0 +1,99 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+)
+
+// Attempt is the record of one attempt at a run, kept whether or not it was finished.
+type Attempt struct {
+	Began, Ended time.Time
+	// Splits holds the run time at each split reached. An attempt reset part way through
+	// has fewer splits than the run has segments.
+	Splits []time.Duration
+	// Pauses are the times the attempt was paused, which are left out of its split times.
+	Pauses []Pause `json:",omitempty"`
+	// Firings are the rules that fired during the attempt.
+	Firings []RuleFiring `json:",omitempty"`
+}
+
+// Reached returns how many splits the attempt reached before it ended.
+func (a Attempt) Reached() int {
+	return len(a.Splits)
+}
+
+// SegmentTime returns how long the segment at idx took in the attempt,
+// and false if the attempt didn't get that far.
+func (a Attempt) SegmentTime(idx int) (time.Duration, bool) {
+	if idx < 0 || idx >= len(a.Splits) {
+		return 0, false
+	}
+	if idx == 0 {
+		return a.Splits[0], true
+	}
+	return a.Splits[idx] - a.Splits[idx-1], true
+}
+
+// PausedTime returns the total time the attempt spent paused.
+func (a Attempt) PausedTime() time.Duration {
+	return pausedTime(a.Pauses, -1)
+}
+
+// SegmentPausedTime returns the time the attempt spent paused during the segment at idx.
+func (a Attempt) SegmentPausedTime(idx int) time.Duration {
+	return pausedTime(a.Pauses, idx)
+}
+
+// FinalTime returns the attempt's time at the last split, and false if it wasn't finished.
+func (a Attempt) FinalTime(run *Run) (time.Duration, bool) {
+	if len(a.Splits) < len(run.Segments) || len(a.Splits) == 0 {
+		return 0, false
+	}
+	return a.Splits[len(a.Splits)-1], true
+}
+
+// recordAttempt adds the attempt in progress to the run's history, if there is one.
+func (t *timer) recordAttempt(now time.Time) {
+	if t.Idle() {
+		return
+	}
+
+	a := Attempt{Began: t.began, Ended: now, Pauses: t.Pauses(), Firings: t.Firings()}
+	if !t.end.IsZero() {
+		a.Ended = t.end
+	}
+	// a pause still going when the attempt ends, ends with it
+	if n := len(a.Pauses); n > 0 && a.Pauses[n-1].End.IsZero() {
+		a.Pauses[n-1].End = a.Ended
+	}
+	for i := 0; i < t.segment && i < len(t.run.Segments); i++ {
+		a.Splits = append(a.Splits, t.run.Segments[i].ActiveRunTime)
+	}
+
+	t.run.History = append(t.run.History, a)
+	t.run.Attempts++
+}
+
+// FindAttempt returns the index of the attempt in the run's history that began and ended when a did, or -1 if there is none.
+func (r *Run) FindAttempt(a Attempt) int {
+	for i, h := range r.History {
+		if h.Began.Equal(a.Began) && h.Ended.Equal(a.Ended) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DeleteAttempt removes the attempt at idx from the run's history and uncounts it, e.g. if it was started by accident.
+// Any PB or golds it set are left alone; they are removed through the PB and gold histories.
+func (r *Run) DeleteAttempt(idx int) error {
+	if idx < 0 || idx >= len(r.History) {
+		return fmt.Errorf("there is no attempt %d", idx)
+	}
+	r.History = append(r.History[:idx:idx], r.History[idx+1:]...)
+	if r.Attempts > 0 {
+		r.Attempts--
+	}
+	r.edits++
+	return nil
+}
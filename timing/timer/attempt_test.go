@@ -0,0 +1,63 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAttempt(t *testing.T) {
+	run := testRun()
+	timer, _ := New(run)
+
+	timer.Restart()
+	assert.Empty(t, run.History, "resetting an idle timer records nothing")
+
+	timer.Split() // starts the timer
+	timer.Split()
+	timer.Restart()
+	assert.Len(t, run.History, 1, "a reset attempt is recorded")
+	assert.Equal(t, 1, run.History[0].Reached(), "the attempt records how far it got")
+	assert.Equal(t, 1, run.Attempts, "the attempt is counted")
+	_, finished := run.History[0].FinalTime(run)
+	assert.False(t, finished, "an attempt reset early has no final time")
+
+	assert.True(t, timer.UndoReset())
+	assert.Empty(t, run.History, "undoing the reset takes the attempt back out of the history")
+	assert.Equal(t, 0, run.Attempts, "undoing the reset uncounts the attempt")
+
+	timer.Discard()
+	assert.Empty(t, run.History, "a discarded attempt is not recorded")
+}
+
+func TestAttemptSegmentTime(t *testing.T) {
+	a := Attempt{Splits: []time.Duration{10 * time.Second, 25 * time.Second}}
+
+	segment, ok := a.SegmentTime(1)
+	assert.True(t, ok)
+	assert.Equal(t, 15*time.Second, segment, "segment times are the difference between splits")
+
+	_, ok = a.SegmentTime(2)
+	assert.False(t, ok, "segments the attempt didn't reach have no time")
+}
+
+func TestDeleteAttempt(t *testing.T) {
+	run := &Run{Segments: []*Split{{}}, Attempts: 2, History: []Attempt{{Splits: []time.Duration{time.Second}}, {Splits: []time.Duration{time.Minute}}}}
+
+	assert.Nil(t, run.DeleteAttempt(0))
+	assert.Equal(t, []Attempt{{Splits: []time.Duration{time.Minute}}}, run.History, "the attempt is removed from the history")
+	assert.Equal(t, 1, run.Attempts, "the attempt is uncounted")
+	assert.NotNil(t, run.DeleteAttempt(1), "an attempt that doesn't exist can't be deleted")
+}
+
+func TestFindAttempt(t *testing.T) {
+	began := time.Unix(1000, 0)
+	run := &Run{History: []Attempt{{Began: began, Ended: began.Add(time.Minute)}, {Began: began.Add(time.Hour), Ended: began.Add(2 * time.Hour)}}}
+
+	assert.Equal(t, 1, run.FindAttempt(run.History[1]))
+	found := run.History[1]
+	assert.Nil(t, run.DeleteAttempt(0))
+	assert.Equal(t, 0, run.FindAttempt(found), "attempts are found wherever they have moved to")
+	assert.Equal(t, -1, run.FindAttempt(Attempt{Began: began}), "attempts no longer in the history aren't found")
+}
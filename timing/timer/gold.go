@@ -0,0 +1,53 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+
+	"speedruntimer/timing/splitter"
+)
+
+type Gold = splitter.Gold
+
+// recordGolds adds the attempt's golds to each segment's gold history.
+func (t *timer) recordGolds(now time.Time) {
+	if !t.end.IsZero() {
+		now = t.end
+	}
+
+	for i := 0; i < t.segment && i < len(t.run.Segments); i++ {
+		s := t.run.Segments[i]
+		if !s.IsGold() {
+			continue
+		}
+
+		if len(s.GoldHistory) == 0 && t.bestAtStart != nil && t.bestAtStart[i] != 0 {
+			// keep the gold from before there was a history, so it can be fallen back on
+			s.GoldHistory = append(s.GoldHistory, Gold{Time: t.bestAtStart[i]})
+		}
+		s.GoldHistory = append(s.GoldHistory, Gold{Time: s.ActiveSegment, Date: now})
+	}
+}
+
+// RemoveGold removes the gold at idx from the gold history of the segment at segment,
+// e.g. because it was set by a timing mistake. The segment's best time falls back to the
+// fastest gold left, or to none if there are none left.
+func (r *Run) RemoveGold(segment, idx int) error {
+	if segment < 0 || segment >= len(r.Segments) {
+		return fmt.Errorf("there is no segment %d", segment)
+	}
+	s := r.Segments[segment]
+	if idx < 0 || idx >= len(s.GoldHistory) {
+		return fmt.Errorf("segment %q has no gold %d", s.Name, idx)
+	}
+
+	s.GoldHistory = append(s.GoldHistory[:idx:idx], s.GoldHistory[idx+1:]...)
+	s.BestSegment = 0
+	for _, g := range s.GoldHistory {
+		if s.BestSegment == 0 || g.Time < s.BestSegment {
+			s.BestSegment = g.Time
+		}
+	}
+	r.edits++
+	return nil
+}
@@ -0,0 +1,27 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldHistory(t *testing.T) {
+	run := testRun() // bests 8s, 14s, 19s
+	timer, _ := New(run)
+
+	finishRun(timer, run)
+	timer.Restart()
+	golds := run.Segments[0].GoldHistory
+	assert.Len(t, golds, 2, "the gold from before the history is kept along with the new one")
+	assert.Equal(t, 8*time.Second, golds[0].Time)
+	assert.Equal(t, run.Segments[0].BestSegment, golds[1].Time, "the last gold is the best segment")
+
+	assert.Nil(t, run.RemoveGold(0, 1))
+	assert.Equal(t, 8*time.Second, run.Segments[0].BestSegment, "removing a gold falls back to the one before")
+	assert.Nil(t, run.RemoveGold(0, 0))
+	assert.Equal(t, time.Duration(0), run.Segments[0].BestSegment, "removing every gold leaves no best segment")
+
+	assert.NotNil(t, run.RemoveGold(0, 0), "a gold that doesn't exist can't be removed")
+}
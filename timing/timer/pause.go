@@ -0,0 +1,56 @@
+package timer
+
+import (
+	"time"
+)
+
+// Pause is one time the timer was paused during an attempt.
+type Pause struct {
+	// Segment is the index of the segment that was being run when the timer was paused.
+	Segment int
+	// End is zero while the timer is still paused.
+	Start, End time.Time
+}
+
+// Duration returns how long the pause lasted, or has lasted so far if it hasn't ended.
+func (p Pause) Duration() time.Duration {
+	if p.End.IsZero() {
+		return time.Since(p.Start)
+	}
+	return p.End.Sub(p.Start)
+}
+
+func (t *timer) beginPause(now time.Time) {
+	t.pauses = append(t.pauses, Pause{Segment: t.segment, Start: now})
+}
+
+func (t *timer) endPause(now time.Time) {
+	if len(t.pauses) > 0 && t.pauses[len(t.pauses)-1].End.IsZero() {
+		t.pauses[len(t.pauses)-1].End = now
+	}
+}
+
+// Pauses returns every pause of the current attempt, in order.
+func (t *timer) Pauses() []Pause {
+	return append([]Pause(nil), t.pauses...)
+}
+
+// PausedTime returns the total time the current attempt has spent paused.
+func (t *timer) PausedTime() time.Duration {
+	return pausedTime(t.pauses, -1)
+}
+
+// SegmentPausedTime returns the time spent paused during the segment at idx in the current attempt.
+func (t *timer) SegmentPausedTime(idx int) time.Duration {
+	return pausedTime(t.pauses, idx)
+}
+
+// pausedTime adds up the pauses during the segment at idx, or every pause if idx is negative.
+func pausedTime(pauses []Pause, idx int) (out time.Duration) {
+	for _, p := range pauses {
+		if idx < 0 || p.Segment == idx {
+			out += p.Duration()
+		}
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauses(t *testing.T) {
+	run := testRun()
+	timer, _ := New(run)
+	timer.Start()
+	timer.Pause()
+	time.Sleep(5 * time.Millisecond)
+	timer.Resume()
+	timer.Split()
+	timer.Pause()
+	time.Sleep(5 * time.Millisecond)
+	timer.Pause() // a second press resumes
+
+	pauses := timer.Pauses()
+	assert.Len(t, pauses, 2, "every pause is recorded")
+	assert.Equal(t, 0, pauses[0].Segment, "pauses record the segment they happened in")
+	assert.Equal(t, 1, pauses[1].Segment, "pauses record the segment they happened in")
+	assert.GreaterOrEqual(t, timer.SegmentPausedTime(0), 5*time.Millisecond, "paused time is counted per segment")
+	assert.Equal(t, timer.SegmentPausedTime(0)+timer.SegmentPausedTime(1), timer.PausedTime(),
+		"the attempt's paused time is the sum of its pauses")
+	assert.Less(t, run.Segments[0].ActiveRunTime, 5*time.Millisecond, "paused time is left out of split times")
+
+	timer.Pause()
+	timer.Stop()
+	assert.False(t, timer.Pauses()[2].End.IsZero(), "stopping ends the pause")
+
+	paused := timer.SegmentPausedTime(0)
+	timer.Restart()
+	assert.Empty(t, timer.Pauses(), "a reset forgets the pauses")
+	assert.Len(t, run.History[0].Pauses, 3, "the attempt keeps its pauses")
+	assert.Equal(t, paused, run.History[0].SegmentPausedTime(0), "the attempt's paused time is kept per segment")
+}
@@ -0,0 +1,67 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+)
+
+// PBRecord is a PB as it was when it was set.
+type PBRecord struct {
+	// Date is when the PB was set. It is zero for a PB set before PBs were recorded.
+	Date time.Time `json:",omitempty"`
+	// Splits holds the run time at every split.
+	Splits []time.Duration
+}
+
+// FinalTime returns the PB's time at the last split.
+func (p PBRecord) FinalTime() time.Duration {
+	if len(p.Splits) == 0 {
+		return 0
+	}
+	return p.Splits[len(p.Splits)-1]
+}
+
+// currentPB returns the PB as it stands in the segments' PB times.
+func (r *Run) currentPB() PBRecord {
+	var p PBRecord
+	for _, s := range r.Segments {
+		p.Splits = append(p.Splits, s.PBTime)
+	}
+	return p
+}
+
+// recordPB adds the attempt in progress to the PB history, before it becomes the PB.
+func (t *timer) recordPB(now time.Time) {
+	if len(t.run.PBHistory) == 0 && t.run.PBTime() != 0 {
+		// keep the PB from before there was a history, so that it can still be restored
+		t.run.PBHistory = append(t.run.PBHistory, t.run.currentPB())
+	}
+
+	p := PBRecord{Date: now}
+	if !t.end.IsZero() {
+		p.Date = t.end
+	}
+	for _, s := range t.run.Segments {
+		p.Splits = append(p.Splits, s.ActiveRunTime)
+	}
+	t.run.PBHistory = append(t.run.PBHistory, p)
+}
+
+// RestorePB makes the PB at idx in the PB history the current PB again, forgetting every later one,
+// e.g. after the later runs were rejected.
+func (r *Run) RestorePB(idx int) error {
+	if idx < 0 || idx >= len(r.PBHistory) {
+		return fmt.Errorf("there is no PB %d to restore", idx)
+	}
+	p := r.PBHistory[idx]
+	if len(p.Splits) != len(r.Segments) {
+		return fmt.Errorf("PB has %d splits, but the run has %d segments", len(p.Splits), len(r.Segments))
+	}
+
+	for i, s := range r.Segments {
+		s.PBTime = p.Splits[i]
+	}
+	r.PBHistory = r.PBHistory[:idx+1]
+	r.edits++
+	return nil
+}
@@ -0,0 +1,29 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPBHistory(t *testing.T) {
+	run := testRun() // PB 10s, 25s, 45s
+	timer, _ := New(run)
+
+	finishRun(timer, run)
+	timer.Restart()
+	assert.Len(t, run.PBHistory, 2, "the PB from before the history is kept along with the new one")
+	assert.Equal(t, 45*time.Second, run.PBHistory[0].FinalTime())
+	assert.Equal(t, run.PBTime(), run.PBHistory[1].FinalTime(), "the last PB is the current one")
+
+	timer.UndoReset()
+	assert.Len(t, run.PBHistory, 0, "undoing the reset takes the PB back out")
+	timer.Restart()
+
+	assert.Nil(t, run.RestorePB(0))
+	assert.Equal(t, 45*time.Second, run.PBTime(), "the older PB is the PB again")
+	assert.Len(t, run.PBHistory, 1, "later PBs are forgotten")
+
+	assert.NotNil(t, run.RestorePB(3), "a PB that doesn't exist can't be restored")
+}
@@ -0,0 +1,133 @@
+package timer
+
+import (
+	"time"
+)
+
+// UndoResetWindow is how long after a reset it can still be undone.
+const UndoResetWindow = 10 * time.Second
+
+// resetSnapshot is everything a reset changes, kept so that it can be undone.
+type resetSnapshot struct {
+	at          time.Time
+	state       State
+	segments    []Split
+	bestAtStart []time.Duration
+	// attempts, history and pbHistory are the run's counts from before the reset,
+	// as a reset can record the attempt and a new PB.
+	attempts, history, pbHistory int
+	// edits is the run's edit count at the reset; once the histories are edited, they can't be cut back.
+	edits int
+}
+
+func (t *timer) beginAttempt() {
+	t.lastReset = nil
+	t.bestAtStart = make([]time.Duration, len(t.run.Segments))
+	for i, s := range t.run.Segments {
+		t.bestAtStart[i] = s.BestSegment
+	}
+}
+
+func (t *timer) clear() {
+	t.start = time.Time{}
+	t.end = time.Time{}
+	t.ballast = time.Duration(0)
+	t.offset = time.Duration(0)
+	t.pauses = nil
+	t.firings = nil
+	t.began = time.Time{}
+	t.segment = 0
+	t.bestAtStart = nil
+}
+
+// IsPB returns if the attempt finished faster than the PB, or if there is no PB yet.
+func (t *timer) IsPB() bool {
+	last := t.run.Segments[len(t.run.Segments)-1]
+	if last.ActiveRunTime == time.Duration(0) {
+		return false
+	}
+	return last.PBTime == time.Duration(0) || last.IsGreen()
+}
+
+// HasNewGolds returns if any segment of the attempt so far was a best segment.
+func (t *timer) HasNewGolds() bool {
+	for i := 0; i < t.segment && i < len(t.run.Segments); i++ {
+		if t.run.Segments[i].IsGold() {
+			return true
+		}
+	}
+	return false
+}
+
+// Discard resets the timer as if the attempt never happened: its golds are forgotten and it can't become the PB.
+func (t *timer) Discard() time.Time {
+	now := time.Now()
+	defer t.record()
+	t.saveUndo(now)
+
+	for i, s := range t.run.Segments {
+		s.Restart(false)
+		if t.bestAtStart != nil {
+			s.BestSegment = t.bestAtStart[i]
+		}
+	}
+
+	t.clear()
+	return now
+}
+
+func (t *timer) saveUndo(now time.Time) {
+	if t.Idle() {
+		t.lastReset = nil
+		return
+	}
+
+	snapshot := &resetSnapshot{
+		at:          now,
+		state:       t.State(),
+		bestAtStart: t.bestAtStart,
+		attempts:    t.run.Attempts,
+		history:     len(t.run.History),
+		pbHistory:   len(t.run.PBHistory),
+		edits:       t.run.edits,
+	}
+	for _, s := range t.run.Segments {
+		snapshot.segments = append(snapshot.segments, *s)
+	}
+	t.lastReset = snapshot
+}
+
+// UndoReset brings back the attempt from before the last Restart() or Discard(),
+// as long as it is within UndoResetWindow and no new attempt has been started.
+// Time since the reset counts towards the attempt, as the run carried on meanwhile.
+// It returns whether there was a reset to undo.
+func (t *timer) UndoReset() bool {
+	if t.lastReset == nil || !t.Idle() || time.Since(t.lastReset.at) > UndoResetWindow {
+		return false
+	}
+	if t.lastReset.edits != t.run.edits {
+		t.lastReset = nil
+		return false
+	}
+
+	for i, s := range t.lastReset.segments {
+		*t.run.Segments[i] = s
+	}
+
+	t.start = t.lastReset.state.Start
+	t.end = t.lastReset.state.End
+	t.ballast = t.lastReset.state.Ballast
+	t.offset = t.lastReset.state.Offset
+	t.pauses = t.lastReset.state.Pauses
+	t.firings = t.lastReset.state.Firings
+	t.began = t.lastReset.state.Began
+	t.run.Attempts = t.lastReset.attempts
+	t.run.History = t.run.History[:t.lastReset.history]
+	t.run.PBHistory = t.run.PBHistory[:t.lastReset.pbHistory]
+	t.segment = t.lastReset.state.Segment
+	t.bestAtStart = t.lastReset.bestAtStart
+	t.lastReset = nil
+
+	t.record()
+	return true
+}
@@ -0,0 +1,85 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// finishRun splits through every segment of a fresh attempt.
+func finishRun(timer Timer, run *Run) {
+	timer.Split() // starts the timer
+	for range run.Segments {
+		timer.Split()
+	}
+}
+
+func TestRestartPB(t *testing.T) {
+	run := &Run{Segments: []*Split{{Name: "Fake Split 1"}, {Name: "Fake Split 2"}}}
+	timer, _ := New(run)
+
+	finishRun(timer, run)
+	final := run.Segments[1].ActiveRunTime
+	timer.Restart()
+	assert.Equal(t, final, run.PBTime(), "the first finished attempt becomes the PB")
+
+	run.Segments[1].PBTime = time.Nanosecond
+	finishRun(timer, run)
+	timer.Restart()
+	assert.Equal(t, time.Nanosecond, run.PBTime(), "a slower attempt does not become the PB")
+}
+
+func TestDiscard(t *testing.T) {
+	run := testRun()
+	timer, _ := New(run)
+
+	finishRun(timer, run)
+	assert.True(t, timer.HasNewGolds(), "an attempt far faster than the best segments has golds")
+	timer.Discard()
+
+	assert.True(t, timer.Idle(), "Discard() resets the timer")
+	assert.Equal(t, testRun().SumOfBest(), run.SumOfBest(), "Discard() forgets the attempt's golds")
+	assert.Equal(t, testRun().PBTime(), run.PBTime(), "Discard() does not record a PB")
+}
+
+func TestUndoReset(t *testing.T) {
+	run := testRun()
+	timer, _ := New(run)
+
+	assert.False(t, timer.UndoReset(), "there is nothing to undo before a reset")
+
+	timer.Split() // starts the timer
+	timer.Split()
+	split := run.Segments[0].ActiveRunTime
+	timer.Restart()
+
+	assert.True(t, timer.UndoReset(), "a reset can be undone")
+	assert.True(t, timer.Running(), "undoing a reset resumes the attempt")
+	assert.Equal(t, 1, timer.CurrentSegment())
+	assert.Equal(t, split, run.Segments[0].ActiveRunTime, "undoing a reset brings back the splits")
+	assert.False(t, timer.UndoReset(), "a reset can only be undone once")
+
+	timer.Discard()
+	timer.Start()
+	assert.False(t, timer.UndoReset(), "a reset can't be undone once a new attempt has started")
+}
+
+func TestUndoResetAfterEdit(t *testing.T) {
+	run := testRun()
+	timer, _ := New(run)
+
+	finishRun(timer, run)
+	timer.Restart()
+	assert.Nil(t, run.RestorePB(0))
+	assert.False(t, timer.UndoReset(), "a reset can't be undone once the history has been edited")
+	assert.True(t, timer.Idle())
+	assert.Len(t, run.PBHistory, 1, "the forgotten PB stays forgotten")
+
+	timer.Split() // starts the timer
+	timer.Split()
+	timer.Restart()
+	assert.Nil(t, run.DeleteAttempt(len(run.History)-1))
+	assert.NotPanics(t, func() { timer.UndoReset() })
+	assert.True(t, timer.Idle(), "deleting an attempt drops the pending undo too")
+}
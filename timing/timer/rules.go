@@ -0,0 +1,117 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+
+	"speedruntimer/timing/formatting"
+)
+
+// RuleKind is what a Rule checks for.
+type RuleKind string
+
+const (
+	// RuleBehindPB fires when the attempt is more than Amount behind the PB at a split.
+	RuleBehindPB RuleKind = "behindpb"
+	// RuleCannotPB fires when even best segments from here on would finish more than Amount slower than the PB.
+	RuleCannotPB RuleKind = "cannotpb"
+)
+
+// RuleAction is what happens when a Rule fires.
+type RuleAction string
+
+const (
+	RuleWarn  RuleAction = "warn"
+	RuleReset RuleAction = "reset"
+)
+
+// Rule picks out dead runs, i.e. attempts that aren't worth carrying on, as they are split.
+type Rule struct {
+	Kind RuleKind
+	// Split is the number of the split the rule is checked at, counting from 1, or 0 for every split.
+	Split  int           `json:",omitempty"`
+	Amount time.Duration `json:",omitempty"`
+	Action RuleAction
+}
+
+func (r Rule) String() string {
+	amount := formatting.DeltaFormatMilliseconds(r.Amount.Milliseconds())
+	switch r.Kind {
+	case RuleBehindPB:
+		return fmt.Sprintf("behind PB by more than %s", amount)
+	case RuleCannotPB:
+		return fmt.Sprintf("best possible time %s or more over PB", amount)
+	}
+	return string(r.Kind)
+}
+
+// validate checks the rule can be applied to a run with the given number of segments.
+func (r Rule) validate(segments int) error {
+	if r.Kind != RuleBehindPB && r.Kind != RuleCannotPB {
+		return fmt.Errorf("unknown rule kind %q", r.Kind)
+	}
+	if r.Action != RuleWarn && r.Action != RuleReset {
+		return fmt.Errorf("unknown rule action %q", r.Action)
+	}
+	if r.Split < 0 || r.Split > segments {
+		return fmt.Errorf("rule is checked at split %d, but the run has %d", r.Split, segments)
+	}
+	return nil
+}
+
+// RuleFiring records a Rule firing during an attempt.
+type RuleFiring struct {
+	// Rule is the index of the rule in the run's Rules.
+	Rule int
+	// Split is the index of the split the rule fired at.
+	Split  int
+	Action RuleAction
+}
+
+// fires returns if the rule fires at the split at idx, which has just been made.
+func (r Rule) fires(run *Run, idx int) bool {
+	if r.Split != 0 && r.Split != idx+1 {
+		return false
+	}
+
+	at := run.Segments[idx].ActiveRunTime
+	switch r.Kind {
+	case RuleBehindPB:
+		pb := run.Segments[idx].PBTime
+		return pb != 0 && at-pb > r.Amount
+	case RuleCannotPB:
+		if run.PBTime() == 0 {
+			return false
+		}
+		best := at
+		for _, s := range run.Segments[idx+1:] {
+			best += s.BestSegment
+		}
+		return best-run.PBTime() > r.Amount
+	}
+	return false
+}
+
+// checkRules records every rule that fires at the split at idx and hasn't fired yet in the attempt.
+func (t *timer) checkRules(idx int) {
+	for i, r := range t.run.Rules {
+		if t.hasFired(i) || !r.fires(t.run, idx) {
+			continue
+		}
+		t.firings = append(t.firings, RuleFiring{Rule: i, Split: idx, Action: r.Action})
+	}
+}
+
+func (t *timer) hasFired(rule int) bool {
+	for _, f := range t.firings {
+		if f.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Firings returns every rule that has fired during the current attempt, in order.
+func (t *timer) Firings() []RuleFiring {
+	return append([]RuleFiring(nil), t.firings...)
+}
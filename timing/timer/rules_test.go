@@ -0,0 +1,37 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleFires(t *testing.T) {
+	run := testRun() // PB 10s, 25s, 45s; bests 8s, 14s, 19s
+	run.Segments[0].ActiveRunTime = 16 * time.Second
+
+	behind := Rule{Kind: RuleBehindPB, Split: 1, Amount: 5 * time.Second}
+	assert.True(t, behind.fires(run, 0), "6s behind is more than 5s behind")
+	behind.Split = 2
+	assert.False(t, behind.fires(run, 0), "rules only fire at their split")
+
+	cannot := Rule{Kind: RuleCannotPB}
+	assert.True(t, cannot.fires(run, 0), "16s + 14s + 19s can't beat 45s")
+	run.Segments[0].ActiveRunTime = 11 * time.Second
+	assert.False(t, cannot.fires(run, 0), "11s + 14s + 19s can still beat 45s")
+}
+
+func TestFirings(t *testing.T) {
+	run := testRun()
+	run.Rules = []Rule{{Kind: RuleBehindPB, Amount: -time.Hour, Action: RuleWarn}}
+	timer, _ := New(run)
+
+	finishRun(timer, run)
+	assert.Equal(t, []RuleFiring{{Rule: 0, Split: 0, Action: RuleWarn}}, timer.Firings(),
+		"a rule fires once per attempt, at the first split it applies to")
+
+	timer.Restart()
+	assert.Empty(t, timer.Firings(), "a reset clears the firings")
+	assert.Len(t, run.History[0].Firings, 1, "firings are recorded with the attempt")
+}
@@ -1,7 +1,10 @@
 package timer
 
 import (
+	"errors"
+	"fmt"
 	"speedruntimer/timing/splitter"
+	"time"
 )
 
 type Split = splitter.Split
@@ -10,9 +13,118 @@ type Run struct {
 	GameName string
 	Category string
 	Segments []*Split
+	Sections []Section `json:",omitempty"`
 	Attempts int
+
+	// MinimumSegment is the shortest time a segment can take.
+	// Splits sooner than this after the previous one are ignored.
+	MinimumSegment time.Duration `json:",omitempty"`
+
+	// StartOffset is the time shown when the timer starts. It is usually negative,
+	// for runs timed from some point after the key is pressed, e.g. -1.5s for a run timed from the first input.
+	StartOffset time.Duration `json:",omitempty"`
+	// Countdown is how long to count down for before the timer starts, or 0 to start straight away.
+	Countdown time.Duration `json:",omitempty"`
+
+	// Rules pick out dead runs as they are split, to warn about them or reset them.
+	Rules []Rule `json:",omitempty"`
+
+	// PBHistory holds every PB, oldest first. The last one is the current PB.
+	PBHistory []PBRecord `json:",omitempty"`
+
+	// History holds every attempt that has been reset, oldest first.
+	History []Attempt `json:",omitempty"`
+
+	// edits counts the changes made to the histories other than by resetting,
+	// which a reset can't be undone across.
+	edits int
+}
+
+// Section groups consecutive segments under one name, e.g. a world and its levels.
+type Section struct {
+	Name string
+	// Size is the number of segments in the section, counting on from the end of the previous one.
+	Size int
+}
+
+// SectionRange is a Section resolved to the indices of its segments.
+type SectionRange struct {
+	Name       string
+	Start, End int // End is exclusive
+}
+
+// Contains returns if the segment at idx belongs to the section.
+func (s SectionRange) Contains(idx int) bool {
+	return idx >= s.Start && idx < s.End
 }
 
 func DefaultRun() *Run {
 	return &Run{Segments: []*splitter.Split{{}}}
 }
+
+// SumOfBest returns the sum of every segment's best time,
+// i.e. the best possible time given the best segments so far.
+func (r *Run) SumOfBest() (out time.Duration) {
+	for _, s := range r.Segments {
+		out += s.BestSegment
+	}
+	return out
+}
+
+// PBSegment returns how long the segment at idx took in the PB run.
+func (r *Run) PBSegment(idx int) time.Duration {
+	if idx == 0 {
+		return r.Segments[idx].PBTime
+	}
+	return r.Segments[idx].PBTime - r.Segments[idx-1].PBTime
+}
+
+// PBTime returns the final time of the PB run.
+func (r *Run) PBTime() time.Duration {
+	return r.Segments[len(r.Segments)-1].PBTime
+}
+
+// Validate checks that the run's settings make sense, e.g. after it is loaded from a file.
+func (r *Run) Validate() error {
+	if len(r.Segments) == 0 {
+		return errors.New("run has no segments")
+	}
+
+	total := 0
+	for _, s := range r.Sections {
+		if s.Size <= 0 {
+			return fmt.Errorf("section %q must have at least one segment", s.Name)
+		}
+		total += s.Size
+	}
+	if total > len(r.Segments) {
+		return fmt.Errorf("sections hold %d segments, but the run only has %d", total, len(r.Segments))
+	}
+
+	for i, rule := range r.Rules {
+		if err := rule.validate(len(r.Segments)); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// SectionRanges returns where each section starts and ends.
+// Sections that would run past the last segment are cut short, and empty ones are left out.
+func (r *Run) SectionRanges() (out []SectionRange) {
+	start := 0
+	for _, s := range r.Sections {
+		if s.Size <= 0 {
+			continue // rejected by Validate, but never let a section move backwards
+		}
+		end := start + s.Size
+		if end > len(r.Segments) {
+			end = len(r.Segments)
+		}
+		if end > start {
+			out = append(out, SectionRange{s.Name, start, end})
+		}
+		start = end
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package timer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRun() *Run {
+	return &Run{Segments: []*Split{
+		{Name: "Fake Split 1", PBTime: 10 * time.Second, BestSegment: 8 * time.Second},
+		{Name: "Fake Split 2", PBTime: 25 * time.Second, BestSegment: 14 * time.Second},
+		{Name: "Fake Split 3", PBTime: 45 * time.Second, BestSegment: 19 * time.Second},
+	}}
+}
+
+func TestSumOfBest(t *testing.T) {
+	assert.Equal(t, 41*time.Second, testRun().SumOfBest(),
+		"SumOfBest() should add up every best segment")
+}
+
+func TestPBSegment(t *testing.T) {
+	r := testRun()
+	assert.Equal(t, 10*time.Second, r.PBSegment(0),
+		"PBSegment() of the first split is its PB split time")
+	assert.Equal(t, 20*time.Second, r.PBSegment(2),
+		"PBSegment() should subtract the previous PB split time")
+	assert.Equal(t, 45*time.Second, r.PBTime(),
+		"PBTime() should be the last split's PB time")
+}
+
+func TestSectionRanges(t *testing.T) {
+	r := testRun()
+	assert.Empty(t, r.SectionRanges(), "a run without sections has no section ranges")
+
+	r.Sections = []Section{{"World 1", 2}, {"World 2", 5}}
+	assert.Equal(t, []SectionRange{{"World 1", 0, 2}, {"World 2", 2, 3}}, r.SectionRanges(),
+		"sections follow on from each other, and are cut short at the last segment")
+
+	data, err := json.Marshal(r)
+	assert.Nil(t, err)
+	loaded := &Run{}
+	assert.Nil(t, json.Unmarshal(data, loaded))
+	assert.Equal(t, r.Sections, loaded.Sections, "sections should survive saving and loading")
+}
+
+func TestValidate(t *testing.T) {
+	r := testRun()
+	r.Sections = []Section{{"World 1", 2}, {"World 2", 1}}
+	assert.Nil(t, r.Validate())
+
+	r.Sections = []Section{{"World 1", -1}, {"World 2", 2}}
+	assert.NotNil(t, r.Validate(), "sections must have at least one segment")
+	assert.Equal(t, []SectionRange{{"World 2", 0, 2}}, r.SectionRanges(), "a negative section never moves the next one backwards")
+
+	r.Sections = []Section{{"World 1", 2}, {"World 2", 2}}
+	assert.NotNil(t, r.Validate(), "sections can't hold more segments than the run has")
+
+	assert.NotNil(t, (&Run{}).Validate(), "a run must have segments")
+
+	r = testRun()
+	r.Rules = []Rule{{Kind: RuleBehindPB, Split: 3, Action: RuleReset}}
+	assert.Nil(t, r.Validate())
+	r.Rules = []Rule{{Kind: "behind", Action: RuleReset}}
+	assert.NotNil(t, r.Validate(), "rules must be of a known kind")
+	r.Rules = []Rule{{Kind: RuleCannotPB, Action: "stop"}}
+	assert.NotNil(t, r.Validate(), "rules must have a known action")
+	r.Rules = []Rule{{Kind: RuleBehindPB, Split: 4, Action: RuleWarn}}
+	assert.NotNil(t, r.Validate(), "rules can't be checked at splits the run doesn't have")
+}
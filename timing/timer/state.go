@@ -0,0 +1,94 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is a snapshot of a timer, with enough in it to carry on the attempt later,
+// e.g. after the app crashed part way through a run.
+type State struct {
+	// Began is the wall clock time the attempt was started.
+	Began time.Time `json:",omitempty"`
+	// Start is the wall clock time the timer was last started or resumed.
+	// It is zero while the timer is paused, stopped or idle.
+	Start time.Time
+	// End is the wall clock time the timer was stopped, if it has been.
+	End time.Time
+	// Ballast is the time run before Start, i.e. before the last pause.
+	Ballast time.Duration
+	// Offset is the run time the attempt started at, e.g. negative for a run that starts part way into a load.
+	Offset time.Duration `json:",omitempty"`
+
+	// Pauses holds every pause of the attempt so far.
+	Pauses []Pause `json:",omitempty"`
+	// Firings holds every rule that has fired during the attempt so far.
+	Firings []RuleFiring `json:",omitempty"`
+
+	Segment int
+	// Splits holds the run time at each split completed so far.
+	Splits []time.Duration
+}
+
+// Idle returns if the snapshot is of a timer with no attempt in progress.
+func (s State) Idle() bool {
+	return s.Start.IsZero() && s.End.IsZero() && s.Ballast == time.Duration(0)
+}
+
+// Journal records every change to a timer's state as it happens.
+type Journal interface {
+	Record(State)
+}
+
+func (t *timer) SetJournal(j Journal) {
+	t.journal = j
+}
+
+func (t *timer) record() {
+	if t.journal != nil {
+		t.journal.Record(t.State())
+	}
+}
+
+func (t *timer) State() State {
+	s := State{Began: t.began, Start: t.start, End: t.end, Ballast: t.ballast, Offset: t.offset, Pauses: t.Pauses(), Firings: t.Firings(), Segment: t.segment}
+	for i := 0; i < t.segment && i < len(t.run.Segments); i++ {
+		s.Splits = append(s.Splits, t.run.Segments[i].ActiveRunTime)
+	}
+	return s
+}
+
+// Restore puts the timer back in the given state, redoing the splits made so far.
+// Time spent while the app wasn't running counts towards the run, as it would have if it had been.
+func (t *timer) Restore(s State) error {
+	if s.Segment > len(t.run.Segments) || len(s.Splits) != s.Segment {
+		return fmt.Errorf("saved timer state at segment %d does not fit a run with %d segments", s.Segment, len(t.run.Segments))
+	}
+	// past the last split the timer has stopped, and would have nothing left to split if it hadn't
+	if s.Segment == len(t.run.Segments) && s.End.IsZero() {
+		return fmt.Errorf("saved timer state is past the last segment but was never stopped")
+	}
+
+	for _, seg := range t.run.Segments {
+		seg.Restart(false)
+	}
+	t.beginAttempt()
+
+	prev := time.Duration(0)
+	for i, at := range s.Splits {
+		t.run.Segments[i].Split(at, prev)
+		prev = at
+	}
+
+	t.start = s.Start
+	t.end = s.End
+	t.ballast = s.Ballast
+	t.offset = s.Offset
+	t.began = s.Began
+	t.pauses = append([]Pause(nil), s.Pauses...)
+	t.firings = append([]RuleFiring(nil), s.Firings...)
+	t.segment = s.Segment
+
+	t.record()
+	return nil
+}
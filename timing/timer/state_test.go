@@ -0,0 +1,71 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeJournal struct {
+	states []State
+}
+
+func (j *fakeJournal) Record(s State) {
+	j.states = append(j.states, s)
+}
+
+func TestJournal(t *testing.T) {
+	j := &fakeJournal{}
+	timer, _ := New(testRun())
+	timer.SetJournal(j)
+
+	timer.Split() // starts the timer
+	timer.Split()
+	last := j.states[len(j.states)-1]
+	assert.Equal(t, timer.State(), last, "every event is recorded")
+	assert.Len(t, last.Splits, 1, "completed splits are recorded")
+
+	timer.Restart()
+	assert.True(t, j.states[len(j.states)-1].Idle(), "a reset is recorded as idle")
+}
+
+func TestRestore(t *testing.T) {
+	run := testRun()
+	crashed, _ := New(run)
+	crashed.Split() // starts the timer
+	crashed.Split()
+	saved := crashed.State()
+	// pretend the app was closed for a minute
+	saved.Start = saved.Start.Add(-time.Minute)
+
+	run = testRun()
+	timer, _ := New(run)
+	assert.Nil(t, timer.Restore(saved))
+	assert.True(t, timer.Running(), "a running timer is restored running")
+	assert.Equal(t, 1, timer.CurrentSegment(), "the segment is restored")
+	assert.Equal(t, saved.Splits[0], run.Segments[0].ActiveRunTime, "completed splits are redone")
+	assert.GreaterOrEqual(t, timer.Elapsed(), time.Minute, "time while the app was closed counts")
+
+	saved.Segment = 10
+	assert.NotNil(t, timer.Restore(saved), "a state that doesn't fit the run can't be restored")
+}
+
+func TestRestoreFinished(t *testing.T) {
+	run := testRun()
+	crashed, _ := New(run)
+	for i := 0; i <= len(run.Segments); i++ {
+		crashed.Split() // the first starts the timer, the last stops it
+	}
+	saved := crashed.State()
+	assert.False(t, saved.End.IsZero(), "the journal ends on the final split")
+
+	run = testRun()
+	timer, _ := New(run)
+	assert.Nil(t, timer.Restore(saved))
+	assert.False(t, timer.Running(), "a finished attempt is restored stopped")
+	assert.NotPanics(t, func() { timer.Split() }, "splitting after the last segment does nothing")
+
+	saved.End = time.Time{}
+	assert.NotNil(t, timer.Restore(saved), "a state past the last segment that never stopped can't be restored")
+}
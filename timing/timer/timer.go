@@ -10,6 +10,8 @@ type Timer interface {
 	Start() time.Time
 	Stop() time.Time
 	Restart() time.Time
+	Discard() time.Time
+	UndoReset() bool
 	Pause() time.Time
 	Split() time.Time
 	Resume()
@@ -18,18 +20,45 @@ type Timer interface {
 	Running() bool
 	Paused() bool
 	Stopped() bool
+	HasNewGolds() bool
+	IsPB() bool
 
 	String() string
 	Elapsed() time.Duration
 	GetSplit(int) Split
+	CurrentSegment() int
+	PreviousSplitTime() time.Duration
+	SegmentElapsed() time.Duration
+	CountdownRemaining() time.Duration
+	Pauses() []Pause
+	PausedTime() time.Duration
+	SegmentPausedTime(int) time.Duration
+	Firings() []RuleFiring
+
+	State() State
+	Restore(State) error
+	SetJournal(Journal)
 }
 
 type timer struct {
 	start, end time.Time // end is redundant info now that we have the Run pointer
 	ballast    time.Duration
+	// offset is the run time at start, copied from the run when the attempt begins.
+	offset time.Duration
+	pauses []Pause
+	// firings are the rules that have fired during the attempt.
+	firings []RuleFiring
+	// began is the wall clock time the attempt was started.
+	began time.Time
 
 	run     *Run
 	segment int
+
+	journal Journal
+
+	// bestAtStart holds each segment's best time from before the attempt, so it can be discarded.
+	bestAtStart []time.Duration
+	lastReset   *resetSnapshot
 }
 
 func New(run *Run) (Timer, error) {
@@ -56,55 +85,73 @@ func New(run *Run) (Timer, error) {
 
 func (t *timer) Start() time.Time {
 	now := time.Now()
+	defer t.record()
 
 	// should never occur, but just in case
 	if t.Paused() {
 		return now
 	}
 
+	if t.Idle() {
+		t.beginAttempt()
+		t.began = now
+	}
+
 	t.end = time.Time{}
 	t.ballast = time.Duration(0)
-	t.start = now
+	t.offset = t.run.StartOffset
+	// during a countdown the timer is running, but with a start time still to come
+	t.start = now.Add(t.run.Countdown)
 	return now
 }
 
 func (t *timer) Stop() time.Time {
 	now := time.Now()
+	defer t.record()
 
-	if t.Stopped() {
-		t.Restart()
+	// resetting is done with Restart() or Discard(), so that it can't happen by accident
+	if t.Stopped() || t.Idle() {
 		return now
 	}
 
-	// should never occur, but just in case
-	if t.Idle() {
+	// stopping during the countdown calls off the attempt, as nothing has been timed yet
+	if t.CountdownRemaining() > 0 {
+		t.clear()
 		return now
 	}
 
 	if t.Running() {
 		t.ballast += time.Since(t.start)
 	}
+	t.endPause(now)
 	t.start = time.Time{}
 	t.end = now
 	return now
 }
 
+// Restart resets the timer, keeping the attempt's golds, and making it the PB if it was one.
 func (t *timer) Restart() time.Time {
 	now := time.Now()
-	t.start = time.Time{}
-	t.end = time.Time{}
-	t.ballast = time.Duration(0)
-	t.segment = 0
-
+	defer t.record()
+	t.saveUndo(now)
+	t.recordAttempt(now)
+	t.recordGolds(now)
+
+	isPB := t.IsPB()
+	if isPB {
+		t.recordPB(now)
+	}
 	for _, s := range t.run.Segments {
-		s.Restart(t.run.Segments[len(t.run.Segments)-1].IsGreen())
+		s.Restart(isPB)
 	}
 
+	t.clear()
 	return now
 }
 
 func (t *timer) Pause() time.Time {
 	now := time.Now()
+	defer t.record()
 
 	if t.Paused() {
 		t.Resume()
@@ -112,26 +159,28 @@ func (t *timer) Pause() time.Time {
 	}
 
 	// should never occur, but just in case
-	if !t.Running() {
+	if !t.Running() || t.CountdownRemaining() > 0 {
 		return now
 	}
 
 	t.ballast += time.Since(t.start)
 	t.start = time.Time{}
+	t.beginPause(now)
 	return now
 }
 
 func (t *timer) Split() time.Time {
 	now := time.Now()
+	defer t.record()
 
 	if t.Idle() {
 		t.Start()
 		return now
 	}
 
-	sinceStart := (now.Sub(t.start)) + time.Duration(t.ballast.Milliseconds())
+	sinceStart := now.Sub(t.start) + t.ballast + t.offset
 
-	if !t.Running() {
+	if !t.Running() || t.CountdownRemaining() > 0 {
 		return now
 	}
 
@@ -139,10 +188,22 @@ func (t *timer) Split() time.Time {
 		return now // TODO: probably want to do some actual error reporting here
 	}
 
+	// a run time of zero means a segment wasn't split, so a split can't be made until the run is under way;
+	// the countdown check above should already rule this out, but a negative offset must never slip through
+	if sinceStart <= 0 {
+		return now
+	}
+
 	segment := t.run.Segments[t.segment]
 	prev := t.previousSegment()
 
+	// a segment this short is almost certainly a double press, not a real split
+	if sinceStart-prev.ActiveRunTime < t.run.MinimumSegment {
+		return now
+	}
+
 	segment.Split(sinceStart, prev.ActiveRunTime)
+	t.checkRules(t.segment)
 
 	if t.segment == len(t.run.Segments)-1 {
 		t.Stop()
@@ -154,12 +215,14 @@ func (t *timer) Split() time.Time {
 
 func (t *timer) Resume() {
 	now := time.Now()
+	defer t.record()
 
 	// should never occur, but just in case
 	if !t.Paused() {
 		return
 	}
 
+	t.endPause(now)
 	t.start = now
 }
 
@@ -180,7 +243,7 @@ func (t *timer) Stopped() bool {
 }
 
 func (t *timer) String() string {
-	return formatting.TimeFormatMilliseconds(int64(t.Elapsed()))
+	return formatting.TimeFormatMilliseconds(t.Elapsed().Milliseconds())
 }
 
 // This is suitable for display but NOT for calculation
@@ -188,14 +251,58 @@ func (t *timer) String() string {
 // and is not representative of the time the keypress
 // event was received
 func (t *timer) Elapsed() time.Duration {
-	totalTime := t.ballast.Milliseconds()
-	if t.Running() {
-		totalTime += time.Since(t.start).Milliseconds()
+	if t.Idle() {
+		return t.run.StartOffset
+	}
+
+	totalTime := t.offset + t.ballast
+	if t.Running() && t.CountdownRemaining() == 0 {
+		totalTime += time.Since(t.start)
 	}
 
-	return time.Duration(totalTime) // this is scaled down by a factor of time.Millisecond
+	return totalTime
 }
 
 func (t *timer) GetSplit(idx int) Split {
 	return *t.run.Segments[idx]
 }
+
+// CurrentSegment returns the index of the segment being run.
+// Once the last segment is split this is equal to len(run.Segments).
+func (t *timer) CurrentSegment() int {
+	return t.segment
+}
+
+// PreviousSplitTime returns the run time at the most recent split, or 0 before the first one.
+func (t *timer) PreviousSplitTime() time.Duration {
+	if t.segment == 0 {
+		return time.Duration(0)
+	}
+	return t.run.Segments[t.segment-1].ActiveRunTime
+}
+
+// SegmentElapsed returns the time spent in the current segment,
+// or the time of the final segment once the run is finished.
+// Like Elapsed, this is suitable for display but NOT for calculation.
+func (t *timer) SegmentElapsed() time.Duration {
+	if t.segment >= len(t.run.Segments) {
+		last := len(t.run.Segments) - 1
+		if last == 0 {
+			return t.run.Segments[last].ActiveRunTime
+		}
+		return t.run.Segments[last].ActiveRunTime - t.run.Segments[last-1].ActiveRunTime
+	}
+	return t.Elapsed() - t.PreviousSplitTime()
+}
+
+// CountdownRemaining returns how long is left of the countdown before the timer starts,
+// or 0 if it isn't counting down.
+func (t *timer) CountdownRemaining() time.Duration {
+	if !t.Running() {
+		return 0
+	}
+	if remaining := time.Until(t.start); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -2,6 +2,7 @@ package timer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -129,12 +130,11 @@ func TestStopped(t *testing.T) {
 	timer.Start()
 	assert.True(t, timer.Running(), "Stopped + Start() starts the timer")
 
-	// NOTE: Stopped + Stop() should now be equivalent to Stopped + Restart()
-	// timer, _ = New(run)
-	// timer.Start()
-	// timer.Stop()
-	// timer.Stop()
-	// assert.True(t, timer.Stopped(), "Stopped + Stop() remains stopped")
+	timer, _ = New(run)
+	timer.Start()
+	timer.Stop()
+	timer.Stop()
+	assert.True(t, timer.Stopped(), "Stopped + Stop() remains stopped")
 
 	timer, _ = New(run)
 	timer.Start()
@@ -154,3 +154,83 @@ func TestStopped(t *testing.T) {
 	timer.Resume()
 	assert.True(t, timer.Stopped(), "Stopped + Resume() remains stopped")
 }
+
+func TestCurrentSegment(t *testing.T) {
+	timer, _ := New(testRun())
+	assert.Equal(t, 0, timer.CurrentSegment(), "timer starts on the first segment")
+
+	timer.Split() // starts the timer
+	timer.Split()
+	assert.Equal(t, 1, timer.CurrentSegment(), "Split() advances to the next segment")
+
+	timer.Restart()
+	assert.Equal(t, 0, timer.CurrentSegment(), "Restart() returns to the first segment")
+}
+
+func TestSegmentElapsed(t *testing.T) {
+	run := testRun()
+	timer, _ := New(run)
+	assert.Zero(t, timer.PreviousSplitTime(), "there is no previous split before the first split")
+
+	timer.Split() // starts the timer
+	timer.Split()
+	assert.Equal(t, run.Segments[0].ActiveRunTime, timer.PreviousSplitTime(),
+		"PreviousSplitTime() should be the time of the last split")
+	assert.GreaterOrEqual(t, timer.SegmentElapsed(), time.Duration(0),
+		"SegmentElapsed() should count from the last split")
+
+	timer.Split()
+	timer.Split()
+	assert.Equal(t, run.Segments[2].ActiveRunTime-run.Segments[1].ActiveRunTime, timer.SegmentElapsed(),
+		"SegmentElapsed() should show the final segment once the run is finished")
+}
+
+func TestMinimumSegment(t *testing.T) {
+	run := testRun()
+	run.MinimumSegment = time.Hour
+	timer, _ := New(run)
+
+	timer.Split() // starts the timer
+	timer.Split()
+	assert.Equal(t, 0, timer.CurrentSegment(), "Split() is ignored before the minimum segment time")
+	assert.Zero(t, run.Segments[0].ActiveRunTime)
+
+	run.MinimumSegment = 0
+	timer.Split()
+	assert.Equal(t, 1, timer.CurrentSegment(), "Split() works normally without a minimum segment time")
+}
+
+func TestStartOffset(t *testing.T) {
+	offsetRun := &Run{Segments: []*Split{{}}, StartOffset: -1500 * time.Millisecond}
+	timer, _ := New(offsetRun)
+	assert.Equal(t, -1500*time.Millisecond, timer.Elapsed(), "an idle timer shows the start offset")
+
+	timer.Start()
+	assert.Less(t, timer.Elapsed(), time.Duration(0), "the timer starts at the offset")
+
+	timer.Split()
+	assert.Equal(t, 0, timer.CurrentSegment(), "splits before the offset has run down are ignored")
+}
+
+func TestCountdown(t *testing.T) {
+	countdownRun := &Run{Segments: []*Split{{}, {}}, Countdown: time.Hour}
+	timer, _ := New(countdownRun)
+	timer.Start()
+	assert.True(t, timer.Running(), "the timer is running while counting down")
+	assert.Greater(t, timer.CountdownRemaining(), time.Duration(0), "the countdown has started")
+	assert.Equal(t, time.Duration(0), timer.Elapsed(), "no time passes during the countdown")
+
+	timer.Split()
+	assert.Equal(t, 0, timer.CurrentSegment(), "splits are ignored during the countdown")
+	timer.Pause()
+	assert.False(t, timer.Paused(), "pausing is ignored during the countdown")
+
+	timer.Stop()
+	assert.True(t, timer.Idle(), "stopping during the countdown calls off the attempt")
+
+	countdownRun.Countdown = time.Millisecond
+	timer.Start()
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, time.Duration(0), timer.CountdownRemaining(), "the countdown ends")
+	assert.Greater(t, timer.Elapsed(), time.Duration(0), "the timer starts after the countdown")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"speedruntimer/timing/formatting"
+	"speedruntimer/timing/stats"
+)
+
+// trendWindow is how many attempts the rolling averages are taken over.
+const trendWindow = 10
+
+// formatTime formats a charted time, which is in nanoseconds.
+func formatTime(v float64) string {
+	return formatting.TimeFormatMilliseconds(time.Duration(v).Milliseconds())
+}
+
+func formatCount(v float64) string {
+	return fmt.Sprint(int64(v))
+}
+
+// trendStats charts how the run has improved over time, one series at a time.
+func (a *timerApp) trendStats(w fyne.Window) fyne.CanvasObject {
+	type series struct {
+		name   string
+		points func() []chartPoint
+		format func(float64) string
+	}
+
+	all := []series{
+		{"PB progression", func() []chartPoint { return durationPoints(stats.PBProgression(a.run)) }, formatTime},
+		{fmt.Sprintf("Average of last %d finished runs", trendWindow),
+			func() []chartPoint { return durationPoints(stats.RollingAverage(a.run, trendWindow)) }, formatTime},
+		{"Attempts per day", func() []chartPoint {
+			var out []chartPoint
+			for _, d := range stats.AttemptsPerDay(a.run) {
+				out = append(out, chartPoint{d.Day, float64(d.Attempts)})
+			}
+			return out
+		}, formatCount},
+	}
+	for i, s := range a.run.Segments {
+		i := i
+		all = append(all,
+			series{s.Name + ": average of last " + fmt.Sprint(trendWindow),
+				func() []chartPoint { return durationPoints(stats.SegmentRollingAverage(a.run, i, trendWindow)) }, formatTime},
+			series{s.Name + ": golds", func() []chartPoint { return durationPoints(stats.GoldsOverTime(a.run, i)) }, formatTime},
+		)
+	}
+
+	var names []string
+	for _, s := range all {
+		names = append(names, s.name)
+	}
+
+	chart := container.NewMax()
+	choice := widget.NewSelect(names, func(name string) {
+		for _, s := range all {
+			if s.name == name {
+				chart.Objects = []fyne.CanvasObject{newTimeChart(s.points(), s.format)}
+				chart.Refresh()
+			}
+		}
+	})
+	choice.SetSelectedIndex(0)
+
+	export := widget.NewButton("Export CSV...", func() {
+		dialog.ShowFileSave(func(f fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if f == nil {
+				return // cancelled
+			}
+			defer f.Close()
+			if err := stats.WriteTrendsCSV(f, a.run, trendWindow); err != nil {
+				dialog.ShowError(err, w)
+			}
+		}, w)
+	})
+
+	return container.NewBorder(container.NewBorder(nil, nil, nil, export, choice), nil, nil, nil, chart)
+}
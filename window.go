@@ -0,0 +1,47 @@
+package main
+
+import (
+	"speedruntimer/config"
+
+	"fyne.io/fyne/v2"
+)
+
+// Fyne does not expose the window position, so only the size is remembered.
+
+// restoreWindowSize resizes the timer window to the size saved for the current split file,
+// or to fit its content if there is none.
+func (a *timerApp) restoreWindowSize() {
+	size, ok := a.conf.WindowSizes[a.conf.LastSplitFile]
+	if !ok {
+		a.window.Resize(a.window.Content().MinSize())
+		return
+	}
+	a.window.Resize(fyne.NewSize(size.Width, size.Height))
+}
+
+func (a *timerApp) saveWindowSize() {
+	if a.conf.LastSplitFile == "" {
+		return // sizes are per split file, and there is none loaded
+	}
+	if a.conf.WindowSizes == nil {
+		a.conf.WindowSizes = map[string]config.WindowSize{}
+	}
+
+	size := a.window.Canvas().Size()
+	a.conf.WindowSizes[a.conf.LastSplitFile] = config.WindowSize{Width: size.Width, Height: size.Height}
+	a.saveConfig()
+}
+
+// toggleWindowResizing lets the timer window be resized by hand, or fixes it at its current size.
+// The size is saved when fixed, since it cannot change again until the next toggle.
+func (a *timerApp) toggleWindowResizing(item *fyne.MenuItem) {
+	if a.window.FixedSize() {
+		a.window.SetFixedSize(false)
+		item.Label = "Lock Window Size"
+	} else {
+		a.window.SetFixedSize(true)
+		a.saveWindowSize()
+		item.Label = "Unlock Window Size"
+	}
+	a.window.MainMenu().Refresh()
+}